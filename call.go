@@ -0,0 +1,31 @@
+package sellium
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/Sellium-site/sellium-go/core"
+)
+
+// Call, Get, Post, Patch and Delete reach endpoints the services do not cover
+// yet. They unwrap the response envelope into T; see core.Call.
+
+func Call[T any](ctx context.Context, c *Client, method, path string, query url.Values, body any, opts ...CallOption) (T, *ResponseMeta, error) {
+	return core.Call[T](ctx, c.core, method, path, query, body, opts...)
+}
+
+func Get[T any](ctx context.Context, c *Client, path string, query url.Values, opts ...CallOption) (T, *ResponseMeta, error) {
+	return core.Get[T](ctx, c.core, path, query, opts...)
+}
+
+func Post[T any](ctx context.Context, c *Client, path string, body any, opts ...CallOption) (T, *ResponseMeta, error) {
+	return core.Post[T](ctx, c.core, path, body, opts...)
+}
+
+func Patch[T any](ctx context.Context, c *Client, path string, body any, opts ...CallOption) (T, *ResponseMeta, error) {
+	return core.Patch[T](ctx, c.core, path, body, opts...)
+}
+
+func Delete[T any](ctx context.Context, c *Client, path string, opts ...CallOption) (T, *ResponseMeta, error) {
+	return core.Delete[T](ctx, c.core, path, opts...)
+}
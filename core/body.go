@@ -0,0 +1,163 @@
+package core
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// EncodeFunc is a request body written by a callback, for payloads that should
+// not be marshalled in memory first. Pass it to Do (or Post, Patch) in place
+// of the body value. It is called once per attempt and must write the same
+// JSON document every time; an error it returns aborts the call.
+//
+// An io.Reader can be passed the same way and is sent as is. Readers that
+// also implement io.Seeker are rewound between attempts; calls with other
+// readers are never retried.
+type EncodeFunc func(w io.Writer) error
+
+// WithCompression gzips request bodies of at least minBytes bytes. Streamed
+// bodies, whose size is not known up front, are always compressed.
+func WithCompression(minBytes int) Option {
+	return func(c *Client) {
+		c.compress = true
+		c.compressMin = minBytes
+	}
+}
+
+// payload is an encoded request body that can be opened once per attempt.
+type payload struct {
+	data   []byte                  // marshalled body
+	stream func(w io.Writer) error // streamed body, used when data is nil
+	gzip   bool
+	replay bool
+}
+
+func (c *Client) newPayload(body any) (*payload, error) {
+	switch b := body.(type) {
+	case nil:
+		return nil, nil
+	case EncodeFunc:
+		return &payload{stream: b, gzip: c.compress, replay: true}, nil
+	case io.ReadSeeker:
+		start, err := b.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, err
+		}
+		return &payload{
+			stream: func(w io.Writer) error {
+				if _, err := b.Seek(start, io.SeekStart); err != nil {
+					return err
+				}
+				_, err := io.Copy(w, b)
+				return err
+			},
+			gzip:   c.compress,
+			replay: true,
+		}, nil
+	case io.Reader:
+		return &payload{
+			stream: func(w io.Writer) error {
+				_, err := io.Copy(w, b)
+				return err
+			},
+			gzip: c.compress,
+		}, nil
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	if !c.compress || len(data) < c.compressMin {
+		return &payload{data: data, replay: true}, nil
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return &payload{data: buf.Bytes(), gzip: true, replay: true}, nil
+}
+
+// replayable reports whether p can be sent more than once.
+func (p *payload) replayable() bool { return p == nil || p.replay }
+
+// open returns the body for one attempt. Streamed bodies are written through
+// a pipe by a separate goroutine; failed reports the error of the writer, if
+// any, once the request is done.
+func (p *payload) open() (body io.Reader, failed func() error) {
+	none := func() error { return nil }
+	if p == nil {
+		return nil, none
+	}
+	if p.data != nil {
+		return bytes.NewReader(p.data), none
+	}
+
+	pr, pw := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		var w io.Writer = pw
+		var zw *gzip.Writer
+		if p.gzip {
+			zw = gzip.NewWriter(pw)
+			w = zw
+		}
+		err := p.stream(w)
+		if err == nil && zw != nil {
+			err = zw.Close()
+		}
+		if err != io.ErrClosedPipe { // otherwise the transport stopped reading and its error wins
+			done <- err // before closing, so the failure is visible once the transport sees it
+		}
+		pw.CloseWithError(err)
+	}()
+	return pr, func() error {
+		select {
+		case err := <-done:
+			return err
+		default:
+			return nil
+		}
+	}
+}
+
+// gzipBody decompresses a gzip-encoded response body. The gzip header is read
+// lazily so empty bodies (204, 304) do not fail.
+type gzipBody struct {
+	body io.ReadCloser
+	zr   *gzip.Reader
+	err  error
+}
+
+func (g *gzipBody) Read(p []byte) (int, error) {
+	if g.zr == nil && g.err == nil {
+		g.zr, g.err = gzip.NewReader(g.body)
+	}
+	if g.err != nil {
+		return 0, g.err
+	}
+	return g.zr.Read(p)
+}
+
+func (g *gzipBody) Close() error { return g.body.Close() }
+
+// decompress undoes a gzip Content-Encoding the way net/http does when it
+// negotiates compression itself.
+func decompress(res *http.Response) {
+	if !strings.EqualFold(res.Header.Get("Content-Encoding"), "gzip") {
+		return
+	}
+	res.Body = &gzipBody{body: res.Body}
+	res.Header.Del("Content-Encoding")
+	res.Header.Del("Content-Length")
+	res.ContentLength = -1
+	res.Uncompressed = true
+}
@@ -0,0 +1,202 @@
+package core
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// received is a request body as the server saw it.
+type received struct {
+	gzipped bool
+	body    string
+}
+
+// bodyServer records every request body, undoing gzip, and answers with
+// status for the first fail requests and 200 afterwards.
+func bodyServer(t *testing.T, fail int) (*httptest.Server, func() []received) {
+	t.Helper()
+	var (
+		mu   sync.Mutex
+		got  []received
+		hits atomic.Int32
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body io.Reader = r.Body
+		gz := r.Header.Get("Content-Encoding") == "gzip"
+		if gz {
+			zr, err := gzip.NewReader(r.Body)
+			if err != nil {
+				t.Errorf("bad gzip body: %v", err)
+				return
+			}
+			body = zr
+		}
+		b, _ := io.ReadAll(body)
+		mu.Lock()
+		got = append(got, received{gzipped: gz, body: string(b)})
+		mu.Unlock()
+		if int(hits.Add(1)) <= fail {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"success":true,"data":{}}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() []received {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]received(nil), got...)
+	}
+}
+
+func TestCompressionThreshold(t *testing.T) {
+	srv, got := bodyServer(t, 0)
+	c := New("key", "store", WithBaseURL(srv.URL), WithCompression(64))
+
+	small := map[string]string{"name": "x"}
+	large := map[string]string{"name": strings.Repeat("x", 100)}
+	for _, body := range []any{small, large} {
+		if _, err := c.Do(context.Background(), http.MethodPost, "/products", nil, body, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	r := got()
+	if r[0].gzipped || r[0].body != `{"name":"x"}` {
+		t.Errorf("small body: %+v, want it sent as is", r[0])
+	}
+	if !r[1].gzipped || r[1].body != fmt.Sprintf(`{"name":"%s"}`, strings.Repeat("x", 100)) {
+		t.Errorf("large body: gzipped=%v, body %q", r[1].gzipped, r[1].body)
+	}
+
+	plain := New("key", "store", WithBaseURL(srv.URL))
+	plain.Do(context.Background(), http.MethodPost, "/products", nil, large, nil)
+	if r := got(); r[2].gzipped {
+		t.Error("body compressed without WithCompression")
+	}
+}
+
+func TestStreamedBodies(t *testing.T) {
+	srv, got := bodyServer(t, 0)
+	c := New("key", "store", WithBaseURL(srv.URL), WithCompression(1<<20))
+
+	enc := EncodeFunc(func(w io.Writer) error {
+		_, err := io.WriteString(w, `{"streamed":true}`)
+		return err
+	})
+	if _, err := c.Do(context.Background(), http.MethodPost, "/products", nil, enc, nil); err != nil {
+		t.Fatal(err)
+	}
+	if r := got()[0]; !r.gzipped || r.body != `{"streamed":true}` {
+		t.Errorf("streamed body: %+v, want it gzipped regardless of size", r)
+	}
+
+	boom := errors.New("boom")
+	failing := EncodeFunc(func(w io.Writer) error {
+		io.WriteString(w, `{"partial":`)
+		return boom
+	})
+	if _, err := c.Do(context.Background(), http.MethodPost, "/products", nil, failing, nil); !errors.Is(err, boom) {
+		t.Errorf("EncodeFunc error: got %v", err)
+	}
+}
+
+func TestReadSeekerRewoundBetweenRetries(t *testing.T) {
+	srv, got := bodyServer(t, 2)
+	c := New("key", "store", WithBaseURL(srv.URL), WithRetry(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}))
+
+	body := strings.NewReader(`xx{"name":"seek"}`)
+	body.Seek(2, io.SeekStart) // sent from where the reader stands
+	meta, err := c.Do(context.Background(), http.MethodPut, "/products/abc", nil, body, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.Attempts != 3 {
+		t.Fatalf("attempts = %d, want 3", meta.Attempts)
+	}
+	for i, r := range got() {
+		if r.body != `{"name":"seek"}` {
+			t.Errorf("attempt %d sent %q", i+1, r.body)
+		}
+	}
+}
+
+func TestPlainReaderNotRetried(t *testing.T) {
+	srv, got := bodyServer(t, 1)
+	c := New("key", "store", WithBaseURL(srv.URL), WithRetry(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}))
+
+	body := io.MultiReader(strings.NewReader(`{"name":"once"}`)) // hides any Seek method
+	_, err := c.Do(context.Background(), http.MethodPut, "/products/abc", nil, body, nil)
+	if !errors.Is(err, ErrServer) {
+		t.Fatalf("got %v, want the 503", err)
+	}
+	if n := len(got()); n != 1 {
+		t.Fatalf("sent %d times, want 1", n)
+	}
+}
+
+func TestGzipResponses(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept-Encoding") != "gzip" {
+			t.Errorf("Accept-Encoding = %q", r.Header.Get("Accept-Encoding"))
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		switch r.URL.Path {
+		case "/empty":
+			w.WriteHeader(http.StatusNoContent)
+		case "/unchanged":
+			w.WriteHeader(http.StatusNotModified)
+		default:
+			var buf bytes.Buffer
+			zw := gzip.NewWriter(&buf)
+			zw.Write([]byte(`{"success":true,"data":{"id":"p1"}}`))
+			zw.Close()
+			w.Write(buf.Bytes())
+		}
+	}))
+	defer srv.Close()
+	c := New("key", "store", WithBaseURL(srv.URL))
+
+	var out struct {
+		Data struct{ ID string } `json:"data"`
+	}
+	meta, err := c.Do(context.Background(), http.MethodGet, "/products/p1", nil, nil, &out)
+	if err != nil || out.Data.ID != "p1" {
+		t.Fatalf("gzip response: %+v, %v", out, err)
+	}
+	if meta.Headers.Get("Content-Encoding") != "" {
+		t.Error("Content-Encoding left on a decompressed response")
+	}
+	for _, path := range []string{"/empty", "/unchanged"} {
+		if _, err := c.Do(context.Background(), http.MethodGet, path, nil, nil, &out); err != nil {
+			t.Errorf("%s: %v", path, err)
+		}
+	}
+}
+
+func TestGzipResponseTooLarge(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		fmt.Fprintf(zw, `{"success":true,"data":"%s"}`, strings.Repeat("x", 1<<16))
+		zw.Close()
+	}))
+	defer srv.Close()
+
+	// the limit applies to the decompressed size
+	c := New("key", "store", WithBaseURL(srv.URL), WithMaxResponseBytes(1<<12))
+	var out map[string]any
+	if _, err := c.Do(context.Background(), http.MethodGet, "/big", nil, nil, &out); !errors.Is(err, ErrResponseTooLarge) {
+		t.Fatalf("got %v, want ErrResponseTooLarge", err)
+	}
+}
@@ -0,0 +1,209 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned by Do without contacting the API while the
+// circuit breaker is open.
+var ErrCircuitOpen = errors.New("sellium: circuit breaker is open")
+
+type BreakerState int
+
+const (
+	BreakerClosed BreakerState = iota
+	BreakerOpen
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+	return "closed"
+}
+
+// BreakerConfig decides when the breaker trips. A failure is a network error
+// or a 5xx response; 4xx responses, including 429, count as successes.
+type BreakerConfig struct {
+	ConsecutiveFailures int // trip after this many failures in a row; 0 disables
+
+	FailureRate float64       // trip when this share of requests in Window failed; 0 disables
+	MinRequests int           // requests needed in Window before FailureRate applies
+	Window      time.Duration // length of the FailureRate window
+
+	OpenTimeout      time.Duration // time spent open before letting probes through
+	HalfOpenRequests int           // probes allowed while half-open; all must succeed to close
+}
+
+func DefaultBreakerConfig() BreakerConfig {
+	return BreakerConfig{
+		ConsecutiveFailures: 5,
+		FailureRate:         0.5,
+		MinRequests:         20,
+		Window:              time.Minute,
+		OpenTimeout:         30 * time.Second,
+		HalfOpenRequests:    1,
+	}
+}
+
+func WithCircuitBreaker(cfg BreakerConfig) Option {
+	return func(c *Client) { c.breaker = NewCircuitBreaker(cfg) }
+}
+
+// CircuitBreaker returns the client's breaker, or nil when none is configured.
+// A nil breaker reports BreakerClosed.
+func (c *Client) CircuitBreaker() *CircuitBreaker { return c.breaker }
+
+type CircuitBreaker struct {
+	cfg BreakerConfig
+
+	mu          sync.Mutex
+	state       BreakerState
+	generation  uint64
+	openedAt    time.Time
+	consecutive int
+	windowStart time.Time
+	requests    int
+	failures    int
+	probes      int
+	probeOK     int
+}
+
+func NewCircuitBreaker(cfg BreakerConfig) *CircuitBreaker {
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = 30 * time.Second
+	}
+	if cfg.HalfOpenRequests <= 0 {
+		cfg.HalfOpenRequests = 1
+	}
+	return &CircuitBreaker{cfg: cfg, windowStart: time.Now()}
+}
+
+func (b *CircuitBreaker) State() BreakerState {
+	if b == nil {
+		return BreakerClosed
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.advance(time.Now())
+	return b.state
+}
+
+// allow reserves a slot for one request and returns the generation its
+// result has to be reported against.
+func (b *CircuitBreaker) allow() (uint64, error) {
+	if b == nil {
+		return 0, nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.advance(time.Now())
+
+	switch b.state {
+	case BreakerOpen:
+		return 0, ErrCircuitOpen
+	case BreakerHalfOpen:
+		if b.probes >= b.cfg.HalfOpenRequests {
+			return 0, ErrCircuitOpen
+		}
+		b.probes++
+	}
+	return b.generation, nil
+}
+
+type breakerOutcome int
+
+const (
+	breakerSuccess breakerOutcome = iota
+	breakerFailure
+	breakerIgnored // the caller gave up; says nothing about the API
+)
+
+func breakerResult(ctx context.Context, meta *ResponseMeta, err error) breakerOutcome {
+	if err != nil && meta == nil {
+		if ctx.Err() != nil {
+			return breakerIgnored
+		}
+		return breakerFailure
+	}
+	if meta != nil && meta.Status >= 500 {
+		return breakerFailure
+	}
+	return breakerSuccess
+}
+
+func (b *CircuitBreaker) record(gen uint64, outcome breakerOutcome) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if gen != b.generation {
+		return
+	}
+	now := time.Now()
+
+	if b.state == BreakerHalfOpen {
+		switch outcome {
+		case breakerFailure:
+			b.trip(now)
+		case breakerSuccess:
+			b.probeOK++
+			if b.probeOK >= b.cfg.HalfOpenRequests {
+				b.reset(BreakerClosed, now)
+			}
+		case breakerIgnored:
+			b.probes--
+		}
+		return
+	}
+	if outcome == breakerIgnored {
+		return
+	}
+
+	if b.cfg.Window > 0 && now.Sub(b.windowStart) > b.cfg.Window {
+		b.windowStart, b.requests, b.failures = now, 0, 0
+	}
+	b.requests++
+	if outcome == breakerSuccess {
+		b.consecutive = 0
+		return
+	}
+	b.failures++
+	b.consecutive++
+
+	if b.cfg.ConsecutiveFailures > 0 && b.consecutive >= b.cfg.ConsecutiveFailures {
+		b.trip(now)
+		return
+	}
+	if b.cfg.FailureRate > 0 && b.requests >= b.cfg.MinRequests &&
+		float64(b.failures)/float64(b.requests) >= b.cfg.FailureRate {
+		b.trip(now)
+	}
+}
+
+func (b *CircuitBreaker) advance(now time.Time) {
+	if b.state == BreakerOpen && now.Sub(b.openedAt) >= b.cfg.OpenTimeout {
+		b.reset(BreakerHalfOpen, now)
+	}
+}
+
+func (b *CircuitBreaker) trip(now time.Time) {
+	b.reset(BreakerOpen, now)
+	b.openedAt = now
+}
+
+func (b *CircuitBreaker) reset(state BreakerState, now time.Time) {
+	b.state = state
+	b.generation++
+	b.consecutive, b.requests, b.failures = 0, 0, 0
+	b.probes, b.probeOK = 0, 0
+	b.windowStart = now
+}
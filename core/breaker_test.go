@@ -0,0 +1,140 @@
+package core
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBreakerTripsOnConsecutiveFailures(t *testing.T) {
+	b := NewCircuitBreaker(BreakerConfig{ConsecutiveFailures: 3, OpenTimeout: time.Hour})
+	for range 2 {
+		gen, _ := b.allow()
+		b.record(gen, breakerFailure)
+	}
+	gen, _ := b.allow()
+	b.record(gen, breakerSuccess) // resets the run
+	for range 3 {
+		gen, err := b.allow()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b.record(gen, breakerFailure)
+	}
+	if s := b.State(); s != BreakerOpen {
+		t.Fatalf("state = %v, want open", s)
+	}
+	if _, err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("allow while open = %v", err)
+	}
+}
+
+func TestBreakerTripsOnFailureRate(t *testing.T) {
+	b := NewCircuitBreaker(BreakerConfig{FailureRate: 0.5, MinRequests: 4, Window: time.Hour})
+	for _, o := range []breakerOutcome{breakerSuccess, breakerFailure, breakerSuccess} {
+		gen, _ := b.allow()
+		b.record(gen, o)
+	}
+	if s := b.State(); s != BreakerClosed {
+		t.Fatalf("tripped below MinRequests: %v", s)
+	}
+	gen, _ := b.allow()
+	b.record(gen, breakerFailure)
+	if s := b.State(); s != BreakerOpen {
+		t.Fatalf("state = %v, want open at 2/4 failures", s)
+	}
+}
+
+func TestBreakerHalfOpen(t *testing.T) {
+	b := NewCircuitBreaker(BreakerConfig{ConsecutiveFailures: 1, OpenTimeout: 10 * time.Millisecond, HalfOpenRequests: 2})
+	trip := func() {
+		gen, _ := b.allow()
+		b.record(gen, breakerFailure)
+	}
+	trip()
+	time.Sleep(20 * time.Millisecond)
+	if s := b.State(); s != BreakerHalfOpen {
+		t.Fatalf("state = %v, want half-open", s)
+	}
+
+	g1, err1 := b.allow()
+	g2, err2 := b.allow()
+	if err1 != nil || err2 != nil {
+		t.Fatalf("probes rejected: %v, %v", err1, err2)
+	}
+	if _, err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("third probe = %v, want ErrCircuitOpen", err)
+	}
+	b.record(g1, breakerIgnored) // frees its slot
+	g3, err := b.allow()
+	if err != nil {
+		t.Fatalf("probe after an ignored one: %v", err)
+	}
+	b.record(g2, breakerSuccess)
+	b.record(g3, breakerSuccess)
+	if s := b.State(); s != BreakerClosed {
+		t.Fatalf("state = %v, want closed after successful probes", s)
+	}
+
+	trip()
+	time.Sleep(20 * time.Millisecond)
+	gen, _ := b.allow()
+	b.record(gen, breakerFailure)
+	if s := b.State(); s != BreakerOpen {
+		t.Fatalf("state = %v, want open after a failed probe", s)
+	}
+}
+
+func TestBreakerIgnoresStaleResults(t *testing.T) {
+	b := NewCircuitBreaker(BreakerConfig{ConsecutiveFailures: 1, OpenTimeout: 10 * time.Millisecond})
+	stale, _ := b.allow()
+	gen, _ := b.allow()
+	b.record(gen, breakerFailure)
+	time.Sleep(20 * time.Millisecond)
+	probe, _ := b.allow()
+
+	b.record(stale, breakerFailure) // started before the breaker tripped
+	if s := b.State(); s != BreakerHalfOpen {
+		t.Fatalf("stale failure changed state to %v", s)
+	}
+	b.record(probe, breakerSuccess)
+	if s := b.State(); s != BreakerClosed {
+		t.Fatalf("state = %v, want closed", s)
+	}
+}
+
+func TestBreakerConcurrent(t *testing.T) {
+	b := NewCircuitBreaker(BreakerConfig{ConsecutiveFailures: 5, OpenTimeout: time.Millisecond, HalfOpenRequests: 2})
+	var wg sync.WaitGroup
+	for i := range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range 500 {
+				gen, err := b.allow()
+				if err != nil {
+					continue
+				}
+				if (i+j)%3 == 0 {
+					b.record(gen, breakerFailure)
+				} else {
+					b.record(gen, breakerSuccess)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	b.State()
+}
+
+func TestNilBreaker(t *testing.T) {
+	var b *CircuitBreaker
+	if _, err := b.allow(); err != nil {
+		t.Fatal(err)
+	}
+	b.record(0, breakerFailure)
+	if s := b.State(); s != BreakerClosed {
+		t.Fatalf("state = %v", s)
+	}
+}
@@ -0,0 +1,227 @@
+package core
+
+import (
+	"container/list"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CachedResponse is a successful GET response as stored in a Cache.
+type CachedResponse struct {
+	Status       int
+	Header       http.Header
+	Body         []byte
+	ETag         string
+	LastModified string
+	Expires      time.Time
+}
+
+// Cache stores GET responses. Keys start with the store ID and the full
+// request URL, so DeletePrefix can drop a whole collection at once, and end
+// with a digest of the API key and per-call headers the response was
+// fetched with. Implementations must be safe for concurrent use.
+type Cache interface {
+	Get(key string) (*CachedResponse, bool)
+	Set(key string, r *CachedResponse)
+	Delete(key string)
+	DeletePrefix(prefix string)
+}
+
+// CacheConfig enables response caching for GET requests.
+//
+// A cached response is served without contacting the API until it expires.
+// After that, if the API sent an ETag or Last-Modified header, the request is
+// revalidated with If-None-Match / If-Modified-Since and a 304 refreshes the
+// entry. A Cache-Control max-age from the API overrides the configured TTL and
+// no-store disables caching for that response.
+type CacheConfig struct {
+	Cache Cache
+
+	TTL time.Duration // default freshness; 0 means always revalidate
+
+	// RouteTTL overrides TTL per normalized route, e.g. "/products/{id}".
+	// A negative value disables caching for the route.
+	RouteTTL map[string]time.Duration
+}
+
+// WithCache caches GET responses. Any successful POST, PATCH or DELETE made
+// through the same client invalidates the cached responses of the collection
+// it touched, e.g. PATCH /products/abc drops /products and /products/abc.
+func WithCache(cfg CacheConfig) Option {
+	return func(c *Client) {
+		if cfg.Cache == nil {
+			cfg.Cache = NewLRUCache(1024)
+		}
+		c.cache = &responseCache{cfg: cfg}
+	}
+}
+
+type responseCache struct{ cfg CacheConfig }
+
+func (rc *responseCache) ttl(route string) time.Duration {
+	if d, ok := rc.cfg.RouteTTL[route]; ok {
+		return d
+	}
+	return rc.cfg.TTL
+}
+
+// lookup returns the cached entry for key and whether it can be served as is.
+func (rc *responseCache) lookup(key, route string) (*CachedResponse, bool) {
+	if rc == nil || rc.ttl(route) < 0 {
+		return nil, false
+	}
+	e, ok := rc.cfg.Cache.Get(key)
+	if !ok {
+		return nil, false
+	}
+	return e, time.Now().Before(e.Expires)
+}
+
+func (rc *responseCache) store(key, route string, meta *ResponseMeta, raw []byte) {
+	ttl := rc.ttl(route)
+	if ttl < 0 || meta.Status < 200 || meta.Status >= 300 {
+		return
+	}
+	cc := meta.Headers.Get("Cache-Control")
+	if strings.Contains(cc, "no-store") {
+		return
+	}
+	if age, ok := maxAge(cc); ok {
+		ttl = age
+	}
+	e := &CachedResponse{
+		Status:       meta.Status,
+		Header:       meta.Headers.Clone(),
+		Body:         raw,
+		ETag:         meta.Headers.Get("ETag"),
+		LastModified: meta.Headers.Get("Last-Modified"),
+		Expires:      time.Now().Add(ttl),
+	}
+	if ttl <= 0 && e.ETag == "" && e.LastModified == "" {
+		return
+	}
+	rc.cfg.Cache.Set(key, e)
+}
+
+func (rc *responseCache) refresh(key, route string, e *CachedResponse, meta *ResponseMeta) {
+	ttl := rc.ttl(route)
+	if age, ok := maxAge(meta.Headers.Get("Cache-Control")); ok {
+		ttl = age
+	}
+	fresh := *e
+	fresh.Expires = time.Now().Add(ttl)
+	rc.cfg.Cache.Set(key, &fresh)
+}
+
+func (rc *responseCache) invalidate(storeID, baseURL, path string) {
+	if rc == nil {
+		return
+	}
+	collection := path
+	if i := strings.IndexByte(strings.TrimPrefix(path, "/"), '/'); i >= 0 {
+		collection = path[:i+1]
+	}
+	rc.cfg.Cache.DeletePrefix(storeID + " " + baseURL + collection)
+}
+
+func conditional(header http.Header, e *CachedResponse) http.Header {
+	if e.ETag == "" && e.LastModified == "" {
+		return header
+	}
+	h := header.Clone()
+	if e.ETag != "" {
+		h.Set("If-None-Match", e.ETag)
+	}
+	if e.LastModified != "" {
+		h.Set("If-Modified-Since", e.LastModified)
+	}
+	return h
+}
+
+func maxAge(cc string) (time.Duration, bool) {
+	for _, part := range strings.Split(cc, ",") {
+		part = strings.TrimSpace(part)
+		if v, ok := strings.CutPrefix(part, "max-age="); ok {
+			if secs, err := strconv.Atoi(v); err == nil {
+				return time.Duration(secs) * time.Second, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// LRUCache is an in-memory Cache holding at most a fixed number of entries.
+type LRUCache struct {
+	mu       sync.Mutex
+	capacity int
+	ll       *list.List
+	items    map[string]*list.Element
+}
+
+type lruEntry struct {
+	key string
+	val *CachedResponse
+}
+
+func NewLRUCache(capacity int) *LRUCache {
+	if capacity <= 0 {
+		capacity = 1024
+	}
+	return &LRUCache{capacity: capacity, ll: list.New(), items: map[string]*list.Element{}}
+}
+
+func (l *LRUCache) Get(key string) (*CachedResponse, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	el, ok := l.items[key]
+	if !ok {
+		return nil, false
+	}
+	l.ll.MoveToFront(el)
+	return el.Value.(*lruEntry).val, true
+}
+
+func (l *LRUCache) Set(key string, r *CachedResponse) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if el, ok := l.items[key]; ok {
+		el.Value.(*lruEntry).val = r
+		l.ll.MoveToFront(el)
+		return
+	}
+	l.items[key] = l.ll.PushFront(&lruEntry{key: key, val: r})
+	for l.ll.Len() > l.capacity {
+		oldest := l.ll.Back()
+		l.ll.Remove(oldest)
+		delete(l.items, oldest.Value.(*lruEntry).key)
+	}
+}
+
+func (l *LRUCache) Delete(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if el, ok := l.items[key]; ok {
+		l.ll.Remove(el)
+		delete(l.items, key)
+	}
+}
+
+func (l *LRUCache) DeletePrefix(prefix string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for key, el := range l.items {
+		if strings.HasPrefix(key, prefix) {
+			l.ll.Remove(el)
+			delete(l.items, key)
+		}
+	}
+}
+
+func (l *LRUCache) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.ll.Len()
+}
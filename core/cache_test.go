@@ -0,0 +1,217 @@
+package core
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// cacheServer counts requests and answers them with handle.
+func cacheServer(t *testing.T, handle func(w http.ResponseWriter, r *http.Request)) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		handle(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &hits
+}
+
+func cacheGet(t *testing.T, c *Client, path string) *ResponseMeta {
+	t.Helper()
+	var out map[string]any
+	meta, err := c.Do(context.Background(), http.MethodGet, path, nil, nil, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return meta
+}
+
+func writeOK(w http.ResponseWriter) { w.Write([]byte(`{"success":true,"data":{}}`)) }
+
+func TestCacheServesFreshEntries(t *testing.T) {
+	srv, hits := cacheServer(t, func(w http.ResponseWriter, r *http.Request) { writeOK(w) })
+	c := New("key", "store", WithBaseURL(srv.URL), WithCache(CacheConfig{TTL: time.Minute}))
+
+	if meta := cacheGet(t, c, "/products"); meta.Cached {
+		t.Fatal("first response marked cached")
+	}
+	if meta := cacheGet(t, c, "/products"); !meta.Cached {
+		t.Fatal("second response not served from the cache")
+	}
+	if n := hits.Load(); n != 1 {
+		t.Fatalf("server hit %d times, want 1", n)
+	}
+}
+
+func TestCacheRevalidatesWithETag(t *testing.T) {
+	srv, hits := cacheServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.Header().Set("Cache-Control", "max-age=60")
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		writeOK(w)
+	})
+	c := New("key", "store", WithBaseURL(srv.URL), WithCache(CacheConfig{}))
+
+	cacheGet(t, c, "/products")
+	meta := cacheGet(t, c, "/products")
+	if !meta.Cached || meta.Status != http.StatusOK {
+		t.Fatalf("304 not answered from the cache: cached=%v status=%d", meta.Cached, meta.Status)
+	}
+	// the 304 carried max-age=60, so the entry is fresh again
+	cacheGet(t, c, "/products")
+	if n := hits.Load(); n != 2 {
+		t.Fatalf("server hit %d times, want 2", n)
+	}
+}
+
+func TestCacheRevalidatesWithLastModified(t *testing.T) {
+	const modified = "Tue, 05 Mar 2024 14:07:09 GMT"
+	var conditional atomic.Int32
+	srv, _ := cacheServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-Modified-Since") == modified {
+			conditional.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Last-Modified", modified)
+		writeOK(w)
+	})
+	c := New("key", "store", WithBaseURL(srv.URL), WithCache(CacheConfig{}))
+
+	cacheGet(t, c, "/orders")
+	if meta := cacheGet(t, c, "/orders"); !meta.Cached {
+		t.Fatal("304 not answered from the cache")
+	}
+	if n := conditional.Load(); n != 1 {
+		t.Fatalf("%d conditional requests, want 1", n)
+	}
+}
+
+func TestCacheRouteTTLDisables(t *testing.T) {
+	srv, hits := cacheServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		writeOK(w)
+	})
+	c := New("key", "store", WithBaseURL(srv.URL), WithCache(CacheConfig{
+		TTL:      time.Minute,
+		RouteTTL: map[string]time.Duration{"/orders": -1},
+	}))
+
+	for range 2 {
+		if meta := cacheGet(t, c, "/orders"); meta.Cached {
+			t.Fatal("route with a negative TTL was cached")
+		}
+	}
+	cacheGet(t, c, "/products")
+	cacheGet(t, c, "/products")
+	if n := hits.Load(); n != 3 {
+		t.Fatalf("server hit %d times, want 3", n)
+	}
+}
+
+func TestCacheControl(t *testing.T) {
+	srv, hits := cacheServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/secret":
+			w.Header().Set("Cache-Control", "private, no-store")
+		case "/short":
+			w.Header().Set("Cache-Control", "max-age=0")
+		case "/long":
+			w.Header().Set("Cache-Control", "public, max-age=60")
+		}
+		writeOK(w)
+	})
+	c := New("key", "store", WithBaseURL(srv.URL), WithCache(CacheConfig{TTL: time.Minute}))
+
+	for _, path := range []string{"/secret", "/short"} {
+		cacheGet(t, c, path)
+		if meta := cacheGet(t, c, path); meta.Cached {
+			t.Errorf("%s served from the cache", path)
+		}
+	}
+	if n := hits.Load(); n != 4 {
+		t.Fatalf("server hit %d times, want 4", n)
+	}
+
+	c = New("key", "store", WithBaseURL(srv.URL), WithCache(CacheConfig{})) // TTL 0: always revalidate
+	cacheGet(t, c, "/long")
+	if meta := cacheGet(t, c, "/long"); !meta.Cached {
+		t.Error("max-age did not override the configured TTL")
+	}
+}
+
+func TestCacheInvalidatedByWrites(t *testing.T) {
+	srv, hits := cacheServer(t, func(w http.ResponseWriter, r *http.Request) { writeOK(w) })
+	c := New("key", "store", WithBaseURL(srv.URL), WithCache(CacheConfig{TTL: time.Minute}))
+
+	for _, method := range []string{http.MethodPatch, http.MethodDelete, http.MethodPost} {
+		cacheGet(t, c, "/products")
+		cacheGet(t, c, "/products/abc")
+		before := hits.Load()
+		if _, err := c.Do(context.Background(), method, "/products/abc", nil, nil, nil); err != nil {
+			t.Fatal(err)
+		}
+		cacheGet(t, c, "/products")
+		cacheGet(t, c, "/products/abc")
+		if n := hits.Load() - before; n != 3 {
+			t.Errorf("%s: %d requests after the write, want the write and two refetches", method, n)
+		}
+	}
+
+	cacheGet(t, c, "/coupons")
+	before := hits.Load()
+	c.Do(context.Background(), http.MethodDelete, "/products/abc", nil, nil, nil)
+	if meta := cacheGet(t, c, "/coupons"); !meta.Cached || hits.Load()-before != 1 {
+		t.Error("a write dropped another collection")
+	}
+}
+
+func TestCacheNotSharedBetweenAPIKeys(t *testing.T) {
+	srv, hits := cacheServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-Key") != "parent" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"success":false,"error":{"code":"UNAUTHORIZED","message":"bad key"}}`))
+			return
+		}
+		writeOK(w)
+	})
+	parent := New("parent", "store", WithBaseURL(srv.URL), WithCache(CacheConfig{TTL: time.Minute}))
+	cacheGet(t, parent, "/products")
+
+	child := parent.With(WithAPIKey("revoked"))
+	if _, err := child.Do(context.Background(), http.MethodGet, "/products", nil, nil, nil); err == nil {
+		t.Fatal("child served the parent's cached response without its key being checked")
+	}
+	if meta := cacheGet(t, parent, "/products"); !meta.Cached {
+		t.Error("parent lost its cached response")
+	}
+	if n := hits.Load(); n != 2 {
+		t.Fatalf("server hit %d times, want 2", n)
+	}
+}
+
+func TestLRUCache(t *testing.T) {
+	l := NewLRUCache(2)
+	l.Set("s /a", &CachedResponse{})
+	l.Set("s /b", &CachedResponse{})
+	l.Get("s /a")
+	l.Set("s /c", &CachedResponse{}) // evicts /b, the least recently used
+	if _, ok := l.Get("s /b"); ok {
+		t.Error("least recently used entry kept")
+	}
+	if _, ok := l.Get("s /a"); !ok {
+		t.Error("recently used entry evicted")
+	}
+	l.DeletePrefix("s /")
+	if l.Len() != 0 {
+		t.Errorf("Len = %d after DeletePrefix", l.Len())
+	}
+}
@@ -0,0 +1,33 @@
+package core
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// Call requests an arbitrary API path and returns the data member of the
+// response envelope. It is meant for endpoints the services do not cover yet
+// and behaves exactly like them: failures come back as *APIError and every
+// client and call option applies.
+func Call[T any](ctx context.Context, c *Client, method, path string, query url.Values, body any, opts ...CallOption) (T, *ResponseMeta, error) {
+	var out envelope[T]
+	meta, err := c.Do(ctx, method, path, query, body, &out, opts...)
+	return out.Data, meta, err
+}
+
+func Get[T any](ctx context.Context, c *Client, path string, query url.Values, opts ...CallOption) (T, *ResponseMeta, error) {
+	return Call[T](ctx, c, http.MethodGet, path, query, nil, opts...)
+}
+
+func Post[T any](ctx context.Context, c *Client, path string, body any, opts ...CallOption) (T, *ResponseMeta, error) {
+	return Call[T](ctx, c, http.MethodPost, path, nil, body, opts...)
+}
+
+func Patch[T any](ctx context.Context, c *Client, path string, body any, opts ...CallOption) (T, *ResponseMeta, error) {
+	return Call[T](ctx, c, http.MethodPatch, path, nil, body, opts...)
+}
+
+func Delete[T any](ctx context.Context, c *Client, path string, opts ...CallOption) (T, *ResponseMeta, error) {
+	return Call[T](ctx, c, http.MethodDelete, path, nil, nil, opts...)
+}
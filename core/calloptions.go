@@ -0,0 +1,80 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"time"
+)
+
+// CallOption adjusts a single call to Do. Every service method accepts them.
+type CallOption func(*callOptions)
+
+type callOptions struct {
+	header         http.Header
+	timeout        time.Duration
+	baseURL        string
+	storeID        string
+	idempotencyKey *string
+	raw            *RawResponse
+}
+
+// RawResponse receives the undecoded response of a call, see WithRawResponse.
+type RawResponse struct {
+	Status int
+	Header http.Header
+	Body   []byte
+}
+
+// WithHeader adds a request header. The authentication headers cannot be
+// overridden this way; use WithCallStoreID to target another store.
+func WithHeader(key, value string) CallOption {
+	return func(o *callOptions) {
+		if o.header == nil {
+			o.header = http.Header{}
+		}
+		o.header.Add(key, value)
+	}
+}
+
+// WithTimeout bounds the whole call, including retries and rate-limit waits.
+func WithTimeout(d time.Duration) CallOption { return func(o *callOptions) { o.timeout = d } }
+
+func WithCallBaseURL(u string) CallOption { return func(o *callOptions) { o.baseURL = u } }
+
+func WithCallStoreID(id string) CallOption { return func(o *callOptions) { o.storeID = id } }
+
+// WithIdempotencyKey sends key as the Idempotency-Key header of this call; an
+// empty key generates one. See ContextWithIdempotencyKey.
+func WithIdempotencyKey(key string) CallOption {
+	return func(o *callOptions) { o.idempotencyKey = &key }
+}
+
+// WithRawResponse stores the status, headers and body of the response in dst.
+// The body is buffered in full for calls using this option.
+func WithRawResponse(dst *RawResponse) CallOption { return func(o *callOptions) { o.raw = dst } }
+
+func newCallOptions(opts []CallOption) callOptions {
+	var o callOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+func (o *callOptions) apply(ctx context.Context) (context.Context, context.CancelFunc) {
+	if o.idempotencyKey != nil {
+		ctx = ContextWithIdempotencyKey(ctx, *o.idempotencyKey)
+	}
+	if o.timeout > 0 {
+		return context.WithTimeout(ctx, o.timeout)
+	}
+	return ctx, func() {}
+}
+
+func (o *callOptions) capture(meta *ResponseMeta, raw []byte) {
+	if o.raw == nil {
+		return
+	}
+	*o.raw = RawResponse{Status: meta.Status, Header: meta.Headers.Clone(), Body: bytes.Clone(raw)}
+}
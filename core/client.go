@@ -1,36 +1,108 @@
 package core
 
 import (
+	"log/slog"
 	"net/http"
+	"slices"
 	"time"
 )
 
+// Client is safe for concurrent use. Its configuration is fixed once New
+// returns; use With to derive a client with different settings.
 type Client struct {
-	BaseURL   string
-	APIKey    string
-	StoreID   string
-	UserAgent string
-	HTTP      *http.Client
+	baseURL   string
+	apiKey    string
+	storeID   string
+	userAgent string
+	http      *http.Client
+
+	creds     CredentialsProvider
+	retry     *RetryPolicy
+	limiter   *RateLimiter
+	breaker   *CircuitBreaker
+	coalescer *coalescer
+	cache     *responseCache
+
+	middleware       []Middleware
+	maxResponseBytes int64
+	compress         bool
+	compressMin      int
+
+	logger      *slog.Logger
+	logUnmasked bool
+	tracer      Tracer
+	metrics     Metrics
 }
 
 type Option func(*Client)
 
-func WithBaseURL(v string) Option { return func(c *Client) { c.BaseURL = v } }
+func WithBaseURL(v string) Option { return func(c *Client) { c.baseURL = v } }
 func WithHTTPClient(h *http.Client) Option {
-	return func(c *Client) { c.HTTP = h }
+	return func(c *Client) { c.http = h }
+}
+func WithUserAgent(v string) Option { return func(c *Client) { c.userAgent = v } }
+
+// WithAPIKey replaces the API key, including one resolved through an earlier
+// WithCredentialsProvider. It is mainly useful with With.
+func WithAPIKey(v string) Option {
+	return func(c *Client) {
+		c.apiKey = v
+		c.creds = nil
+	}
+}
+
+// WithStoreID replaces the store ID. A credentials provider configured
+// earlier keeps supplying the API key but no longer the store.
+func WithStoreID(v string) Option {
+	return func(c *Client) {
+		c.storeID = v
+		if c.creds != nil {
+			c.creds = storeCredentials{CredentialsProvider: c.creds, storeID: v}
+		}
+	}
 }
-func WithUserAgent(v string) Option { return func(c *Client) { c.UserAgent = v } }
 
 func New(apiKey, storeID string, opts ...Option) *Client {
 	c := &Client{
-		BaseURL:   "https://sellium.site/api/v1",
-		APIKey:    apiKey,
-		StoreID:   storeID,
-		UserAgent: "sellium-go/0.1",
-		HTTP:      &http.Client{Timeout: 30 * time.Second},
+		baseURL:   "https://sellium.site/api/v1",
+		apiKey:    apiKey,
+		storeID:   storeID,
+		userAgent: "sellium-go/0.1",
+		http:      &http.Client{Timeout: 30 * time.Second},
+
+		maxResponseBytes: DefaultMaxResponseBytes,
 	}
 	for _, opt := range opts {
 		opt(c)
 	}
 	return c
 }
+
+// With returns a child client with opts applied on top of c's configuration.
+// The child shares the HTTP client, cache, circuit breaker and coalescing
+// with c unless opts replace them. A child for another store or API key gets
+// its own rate-limit budget; otherwise the limiter is shared as well.
+func (c *Client) With(opts ...Option) *Client {
+	cp := c.clone()
+	for _, opt := range opts {
+		opt(cp)
+	}
+	if cp.limiter == c.limiter && (cp.storeID != c.storeID || cp.apiKey != c.apiKey) {
+		cp.limiter = c.limiter.fork()
+	}
+	return cp
+}
+
+func (c *Client) clone() *Client {
+	cp := *c
+	cp.middleware = slices.Clip(c.middleware) // WithMiddleware must not append into c's slice
+	return &cp
+}
+
+func (c *Client) BaseURL() string   { return c.baseURL }
+func (c *Client) StoreID() string   { return c.storeID }
+func (c *Client) UserAgent() string { return c.userAgent }
+
+// HTTPClient returns the underlying HTTP client, which is shared with every
+// client derived through With.
+func (c *Client) HTTPClient() *http.Client { return c.http }
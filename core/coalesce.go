@@ -0,0 +1,80 @@
+package core
+
+import (
+	"context"
+	"sync"
+)
+
+// WithRequestCoalescing makes concurrent identical GET requests (same store,
+// API key, path, query and per-call headers) share a single HTTP round trip.
+// Each caller still decodes the shared body into its own value and may
+// cancel its own context without affecting the others; the round trip is
+// only canceled once every caller has gone away. The shared request carries
+// the first caller's correlation ID, so the others get a ResponseMeta with
+// Coalesced set and CorrelationID empty.
+func WithRequestCoalescing() Option {
+	return func(c *Client) { c.coalescer = &coalescer{flights: map[string]*flight{}} }
+}
+
+type coalescer struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+type flight struct {
+	done    chan struct{}
+	cancel  context.CancelFunc
+	waiters int
+
+	meta *ResponseMeta
+	raw  []byte
+	err  error
+}
+
+func (g *coalescer) do(ctx context.Context, key string, fn func(context.Context) (*ResponseMeta, []byte, error)) (*ResponseMeta, []byte, error) {
+	g.mu.Lock()
+	f, shared := g.flights[key]
+	if !shared {
+		fctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		f = &flight{done: make(chan struct{}), cancel: cancel}
+		g.flights[key] = f
+		go func() {
+			f.meta, f.raw, f.err = fn(fctx)
+			g.forget(key, f)
+			cancel()
+			close(f.done)
+		}()
+	}
+	f.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-f.done:
+		if f.meta == nil {
+			return nil, f.raw, f.err
+		}
+		meta := *f.meta
+		meta.Headers = f.meta.Headers.Clone()
+		meta.Coalesced = shared
+		return &meta, f.raw, f.err
+	case <-ctx.Done():
+		g.mu.Lock()
+		f.waiters--
+		if f.waiters == 0 {
+			f.cancel()
+			if g.flights[key] == f {
+				delete(g.flights, key)
+			}
+		}
+		g.mu.Unlock()
+		return nil, nil, ctx.Err()
+	}
+}
+
+func (g *coalescer) forget(key string, f *flight) {
+	g.mu.Lock()
+	if g.flights[key] == f {
+		delete(g.flights, key)
+	}
+	g.mu.Unlock()
+}
@@ -0,0 +1,209 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCoalescerSharesRoundTrip(t *testing.T) {
+	var hits atomic.Int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		<-release
+		w.Write([]byte(`{"success":true,"data":{"id":"p1"}}`))
+	}))
+	defer srv.Close()
+
+	c := New("key", "store", WithBaseURL(srv.URL), WithRequestCoalescing())
+	const callers = 10
+	var (
+		wg        sync.WaitGroup
+		coalesced atomic.Int32
+		started   sync.WaitGroup
+	)
+	started.Add(callers)
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			started.Done()
+			var out struct {
+				Data struct{ ID string } `json:"data"`
+			}
+			meta, err := c.Do(context.Background(), http.MethodGet, "/products/p1", nil, nil, &out)
+			if err != nil || out.Data.ID != "p1" {
+				t.Errorf("Do = %+v, %v", out, err)
+				return
+			}
+			if meta.Coalesced {
+				coalesced.Add(1)
+			}
+		}()
+	}
+	started.Wait()
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := hits.Load(); n != 1 {
+		t.Errorf("server hit %d times, want 1", n)
+	}
+	if n := coalesced.Load(); n != callers-1 {
+		t.Errorf("%d responses marked coalesced, want %d", n, callers-1)
+	}
+}
+
+func TestCoalescerCallerCancel(t *testing.T) {
+	g := &coalescer{flights: map[string]*flight{}}
+	release := make(chan struct{})
+	fnCtx := make(chan context.Context, 1)
+	fn := func(ctx context.Context) (*ResponseMeta, []byte, error) {
+		fnCtx <- ctx
+		<-release
+		return &ResponseMeta{Status: http.StatusOK}, []byte("ok"), nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, _, err := g.do(ctx, "k", fn)
+		first <- err
+	}()
+	second := make(chan []byte, 1)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		_, raw, _ := g.do(context.Background(), "k", fn)
+		second <- raw
+	}()
+
+	time.Sleep(30 * time.Millisecond)
+	cancel()
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Fatalf("canceled caller got %v", err)
+	}
+	if (<-fnCtx).Err() != nil {
+		t.Fatal("round trip canceled while another caller still waits")
+	}
+	close(release)
+	if raw := <-second; string(raw) != "ok" {
+		t.Fatalf("remaining caller got %q", raw)
+	}
+}
+
+func TestCoalescerCancelsWhenAllCallersLeave(t *testing.T) {
+	g := &coalescer{flights: map[string]*flight{}}
+	canceled := make(chan struct{})
+	fn := func(ctx context.Context) (*ResponseMeta, []byte, error) {
+		<-ctx.Done()
+		close(canceled)
+		return nil, nil, ctx.Err()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		g.do(ctx, "k", fn)
+		close(done)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	<-done
+
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Fatal("round trip not canceled after its only caller left")
+	}
+	g.mu.Lock()
+	n := len(g.flights)
+	g.mu.Unlock()
+	if n != 0 {
+		t.Errorf("%d flights left behind", n)
+	}
+}
+
+func TestCoalescerKeysOnHeaders(t *testing.T) {
+	var (
+		mu   sync.Mutex
+		seen []string
+	)
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		seen = append(seen, r.Header.Get("Accept-Language"))
+		mu.Unlock()
+		<-release
+		w.Write([]byte(`{"success":true,"data":{"lang":"` + r.Header.Get("Accept-Language") + `"}}`))
+	}))
+	defer srv.Close()
+
+	c := New("key", "store", WithBaseURL(srv.URL), WithRequestCoalescing())
+	var wg sync.WaitGroup
+	for _, lang := range []string{"en", "fr"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var out struct {
+				Data struct{ Lang string } `json:"data"`
+			}
+			meta, err := c.Do(context.Background(), http.MethodGet, "/products/p1", nil, nil, &out, WithHeader("Accept-Language", lang))
+			if err != nil || out.Data.Lang != lang || meta.Coalesced {
+				t.Errorf("%s: got %+v (coalesced %v), %v", lang, out, meta.Coalesced, err)
+			}
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if len(seen) != 2 {
+		t.Errorf("server saw %v, want one request per header value", seen)
+	}
+}
+
+func TestCoalescedCorrelationID(t *testing.T) {
+	release := make(chan struct{})
+	sent := make(chan string, 2)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent <- r.Header.Get("X-Correlation-ID")
+		<-release
+		w.Write([]byte(`{"success":true,"data":{}}`))
+	}))
+	defer srv.Close()
+
+	c := New("key", "store", WithBaseURL(srv.URL), WithRequestCoalescing())
+	metas := make(chan *ResponseMeta, 2)
+	for _, id := range []string{"first", "second"} {
+		go func() {
+			meta, err := c.Do(ContextWithCorrelationID(context.Background(), id), http.MethodGet, "/products/p1", nil, nil, nil)
+			if err != nil {
+				t.Error(err)
+			}
+			metas <- meta
+		}()
+		time.Sleep(20 * time.Millisecond)
+	}
+	close(release)
+
+	for range 2 {
+		meta := <-metas
+		if meta == nil {
+			continue
+		}
+		if meta.Coalesced && meta.CorrelationID != "" {
+			t.Errorf("coalesced response reports correlation ID %q it was not sent with", meta.CorrelationID)
+		}
+		if !meta.Coalesced && meta.CorrelationID != "first" {
+			t.Errorf("leader reports correlation ID %q, want first", meta.CorrelationID)
+		}
+	}
+	if len(sent) != 1 || <-sent != "first" {
+		t.Error("want a single round trip carrying the first caller's ID")
+	}
+}
@@ -0,0 +1,178 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type Credentials struct {
+	APIKey  string `json:"api_key"`
+	StoreID string `json:"store_id"`
+}
+
+// CredentialsProvider resolves the credentials for every request, so keys can
+// be rotated without rebuilding the client. Implementations must be safe for
+// concurrent use.
+type CredentialsProvider interface {
+	Credentials(ctx context.Context) (Credentials, error)
+}
+
+// CredentialsRefresher is implemented by providers that can reload their
+// source on demand. Do calls Refresh once when a request comes back 401.
+type CredentialsRefresher interface {
+	Refresh(ctx context.Context) error
+}
+
+// WithCredentialsProvider makes the client resolve the API key and store ID
+// through p instead of using the values passed to New. An empty store ID from
+// p falls back to the client's. A request answered with 401 is repeated once
+// if re-resolving yields different credentials.
+func WithCredentialsProvider(p CredentialsProvider) Option {
+	return func(c *Client) { c.creds = p }
+}
+
+type StaticCredentials Credentials
+
+func (s StaticCredentials) Credentials(context.Context) (Credentials, error) {
+	return Credentials(s), nil
+}
+
+// EnvCredentials reads the API key and store ID from environment variables on
+// every request. Empty names default to SELLIUM_API_KEY and SELLIUM_STORE_ID.
+// When the store ID variable is unset the client keeps the store ID it was
+// created with.
+type EnvCredentials struct {
+	APIKeyVar  string
+	StoreIDVar string
+}
+
+func (e EnvCredentials) Credentials(context.Context) (Credentials, error) {
+	keyVar, storeVar := e.APIKeyVar, e.StoreIDVar
+	if keyVar == "" {
+		keyVar = "SELLIUM_API_KEY"
+	}
+	if storeVar == "" {
+		storeVar = "SELLIUM_STORE_ID"
+	}
+	creds := Credentials{APIKey: os.Getenv(keyVar), StoreID: os.Getenv(storeVar)}
+	if creds.APIKey == "" {
+		return creds, fmt.Errorf("sellium: environment variable %s is not set", keyVar)
+	}
+	return creds, nil
+}
+
+// FileCredentials reads {"api_key": "...", "store_id": "..."} from a JSON
+// file and reloads it when its modification time changes. The file is
+// checked at most once per interval; Refresh forces a reload. Requests keep
+// using the last good credentials if a reload fails.
+type FileCredentials struct {
+	path     string
+	interval time.Duration
+
+	current   atomic.Pointer[Credentials]
+	mu        sync.Mutex // serializes reloads
+	modTime   time.Time
+	checkedAt atomic.Int64
+}
+
+func NewFileCredentials(path string, interval time.Duration) (*FileCredentials, error) {
+	f := &FileCredentials{path: path, interval: interval}
+	if err := f.Refresh(context.Background()); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *FileCredentials) Credentials(context.Context) (Credentials, error) {
+	if time.Since(time.Unix(0, f.checkedAt.Load())) >= f.interval {
+		_ = f.reload(false)
+	}
+	return *f.current.Load(), nil
+}
+
+func (f *FileCredentials) Refresh(context.Context) error { return f.reload(true) }
+
+func (f *FileCredentials) reload(force bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !force && time.Since(time.Unix(0, f.checkedAt.Load())) < f.interval {
+		return nil // another goroutine just checked
+	}
+	f.checkedAt.Store(time.Now().UnixNano())
+
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return err
+	}
+	if !force && info.ModTime().Equal(f.modTime) {
+		return nil
+	}
+	b, err := os.ReadFile(f.path)
+	if err != nil {
+		return err
+	}
+	var creds Credentials
+	if err := json.Unmarshal(b, &creds); err != nil {
+		return fmt.Errorf("sellium: reading credentials from %s: %w", f.path, err)
+	}
+	if creds.APIKey == "" {
+		return errors.New("sellium: credentials file " + f.path + " has no api_key")
+	}
+	f.modTime = info.ModTime()
+	f.current.Store(&creds)
+	return nil
+}
+
+func (c *Client) credentials(ctx context.Context) (Credentials, error) {
+	if c.creds == nil {
+		return Credentials{APIKey: c.apiKey, StoreID: c.storeID}, nil
+	}
+	creds, err := c.creds.Credentials(ctx)
+	if creds.StoreID == "" {
+		creds.StoreID = c.storeID
+	}
+	return creds, err
+}
+
+// refreshCredentials re-resolves credentials after a 401 and reports whether
+// they differ from the ones that were rejected.
+func (c *Client) refreshCredentials(ctx context.Context, rejected Credentials) (Credentials, bool) {
+	if c.creds == nil {
+		return rejected, false
+	}
+	if r, ok := c.creds.(CredentialsRefresher); ok {
+		if err := r.Refresh(ctx); err != nil {
+			return rejected, false
+		}
+	}
+	fresh, err := c.credentials(ctx)
+	if err != nil || fresh == rejected {
+		return rejected, false
+	}
+	return fresh, true
+}
+
+// storeCredentials pins the store ID of a shared provider for a MultiClient view.
+type storeCredentials struct {
+	CredentialsProvider
+	storeID string
+}
+
+func (s storeCredentials) Credentials(ctx context.Context) (Credentials, error) {
+	creds, err := s.CredentialsProvider.Credentials(ctx)
+	creds.StoreID = s.storeID
+	return creds, err
+}
+
+func (s storeCredentials) Refresh(ctx context.Context) error {
+	if r, ok := s.CredentialsProvider.(CredentialsRefresher); ok {
+		return r.Refresh(ctx)
+	}
+	return nil
+}
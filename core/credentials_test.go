@@ -0,0 +1,36 @@
+package core
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEnvCredentialsKeepStoreID(t *testing.T) {
+	t.Setenv("SELLIUM_API_KEY", "env-key")
+	t.Setenv("SELLIUM_STORE_ID", "")
+
+	var key, store string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key, store = r.Header.Get("X-API-Key"), r.Header.Get("X-Store-ID")
+		w.Write([]byte(`{"success":true,"data":{}}`))
+	}))
+	defer srv.Close()
+
+	c := New("", "store_1", WithBaseURL(srv.URL), WithCredentialsProvider(EnvCredentials{}))
+	if _, err := c.Do(context.Background(), http.MethodGet, "/store", nil, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if key != "env-key" || store != "store_1" {
+		t.Fatalf("sent key %q and store %q", key, store)
+	}
+
+	t.Setenv("SELLIUM_STORE_ID", "store_2")
+	if _, err := c.Do(context.Background(), http.MethodGet, "/store", nil, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if store != "store_2" {
+		t.Fatalf("sent store %q, want the one from the environment", store)
+	}
+}
@@ -0,0 +1,242 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultMaxResponseBytes caps response bodies unless WithMaxResponseBytes
+// says otherwise.
+const DefaultMaxResponseBytes = 32 << 20
+
+var ErrResponseTooLarge = errors.New("sellium: response body exceeds the configured maximum size")
+
+// WithMaxResponseBytes limits how much of a response body is read; larger
+// bodies fail with ErrResponseTooLarge. n <= 0 removes the limit.
+func WithMaxResponseBytes(n int64) Option { return func(c *Client) { c.maxResponseBytes = n } }
+
+func (c *Client) limitBody(r io.Reader) io.Reader {
+	if c.maxResponseBytes <= 0 {
+		return r
+	}
+	return &limitedReader{r: r, n: c.maxResponseBytes}
+}
+
+type limitedReader struct {
+	r io.Reader
+	n int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.n <= 0 {
+		var probe [1]byte
+		if n, _ := l.r.Read(probe[:]); n > 0 {
+			return 0, ErrResponseTooLarge
+		}
+		return 0, io.EOF
+	}
+	if int64(len(p)) > l.n {
+		p = p[:l.n]
+	}
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+	return n, err
+}
+
+// decode reads a response into a pooled buffer and decodes it with a single
+// json.Unmarshal: the success and error members of the envelope are picked
+// up by the same pass that fills out. Error responses are copied out of the
+// buffer so APIError.Raw can carry them.
+func decode(meta *ResponseMeta, body io.Reader, out any) error {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	defer putBuffer(buf)
+	buf.Reset()
+	if _, err := buf.ReadFrom(body); err != nil {
+		return err
+	}
+	return decodeBytes(meta, buf.Bytes(), out)
+}
+
+// decodeBytes decodes a body that is already in memory. raw is not retained.
+func decodeBytes(meta *ResponseMeta, raw []byte, out any) error {
+	if meta.Status < 200 || meta.Status >= 300 {
+		return decodeError(meta, raw)
+	}
+	raw = bytes.TrimLeft(raw, " \t\r\n")
+	if len(raw) == 0 {
+		return nil
+	}
+
+	w := envelopeFor(out)
+	if raw[0] != '{' || w == nil {
+		return decodeBuffered(meta, raw, out)
+	}
+
+	var errBody *APIErrorBody
+	v, success := w.bind(out, &errBody)
+	if err := json.Unmarshal(raw, v.Interface()); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	if !*success && errBody != nil {
+		raw, _ := json.Marshal(envelope[json.RawMessage]{Error: errBody})
+		return newAPIError(meta, errBody, raw)
+	}
+	return nil
+}
+
+var bufferPool = sync.Pool{New: func() any { return new(bytes.Buffer) }}
+
+// maxPooledBuffer keeps the buffers of unusually large responses out of the
+// pool.
+const maxPooledBuffer = 1 << 20
+
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() <= maxPooledBuffer {
+		bufferPool.Put(buf)
+	}
+}
+
+// decodeBuffered handles bodies that cannot be streamed into out.
+func decodeBuffered(meta *ResponseMeta, raw []byte, out any) error {
+	var probe envelope[json.RawMessage]
+	if err := json.Unmarshal(raw, &probe); err == nil && !probe.Success && probe.Error != nil {
+		return decodeError(meta, raw)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(raw, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+func decodeError(meta *ResponseMeta, raw []byte) error {
+	raw = bytes.Clone(raw)
+	var probe envelope[json.RawMessage]
+	if err := json.Unmarshal(raw, &probe); err == nil && probe.Error != nil {
+		return newAPIError(meta, probe.Error, raw)
+	}
+	if meta.Status == http.StatusNotModified {
+		return nil
+	}
+	return httpError(meta, raw)
+}
+
+// httpError is used when the API answered with an error status but without
+// an error envelope; errors.Is still classifies it by status.
+func httpError(meta *ResponseMeta, raw []byte) *APIError {
+	body := &APIErrorBody{Code: "HTTP_ERROR", Message: "request failed"}
+	if text := http.StatusText(meta.Status); text != "" {
+		body.Message += ": " + strings.ToLower(text)
+	}
+	return newAPIError(meta, body, raw)
+}
+
+// envelopeType mirrors a response struct with a pointer to each of its
+// fields, plus the envelope's success and error members. Unmarshalling into
+// a value whose pointers address the fields of out fills out in place.
+type envelopeType struct {
+	typ    reflect.Type
+	fields []int // index in out of wrapper field i+2
+	result int   // index in out of a bool success field, or -1
+}
+
+var envelopeCache sync.Map // reflect.Type -> *envelopeType
+
+// envelopeFor returns the wrapper for out if it is a non-nil pointer to a
+// struct, or nil if the response has to be decoded another way.
+func envelopeFor(out any) *envelopeType {
+	if out == nil {
+		return noOutEnvelope
+	}
+	t := reflect.TypeOf(out)
+	if t.Kind() != reflect.Pointer || t.Elem().Kind() != reflect.Struct || reflect.ValueOf(out).IsNil() {
+		return nil
+	}
+	t = t.Elem()
+	if w, ok := envelopeCache.Load(t); ok {
+		return w.(*envelopeType)
+	}
+
+	w := &envelopeType{result: -1}
+	sfs := []reflect.StructField{
+		{Name: "Success", Type: reflect.TypeFor[*bool](), Tag: `json:"success"`},
+		{Name: "Error", Type: reflect.TypeFor[**APIErrorBody](), Tag: `json:"error"`},
+	}
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.Anonymous {
+			return nil // leave promotion rules to encoding/json
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		name, opts, hasOpts := strings.Cut(sf.Tag.Get("json"), ",")
+		if name == "-" && !hasOpts {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		switch {
+		case strings.EqualFold(name, "error"):
+			continue // always read as the envelope's error
+		case strings.EqualFold(name, "success"):
+			if sf.Type.Kind() != reflect.Bool {
+				return nil
+			}
+			w.result = i
+			continue
+		}
+		tag := name
+		if hasOpts {
+			tag += "," + opts
+		}
+		sfs = append(sfs, reflect.StructField{
+			Name: "F" + strconv.Itoa(i),
+			Type: reflect.PointerTo(sf.Type),
+			Tag:  reflect.StructTag(`json:"` + tag + `"`),
+		})
+		w.fields = append(w.fields, i)
+	}
+	w.typ = reflect.StructOf(sfs)
+	envelopeCache.Store(t, w)
+	return w
+}
+
+var noOutEnvelope = &envelopeType{
+	typ: reflect.TypeFor[struct {
+		Success *bool          `json:"success"`
+		Error   **APIErrorBody `json:"error"`
+	}](),
+	result: -1,
+}
+
+// bind returns a pointer to a new wrapper whose fields address errBody and
+// the fields of out, and where the success member will be stored.
+func (w *envelopeType) bind(out any, errBody **APIErrorBody) (reflect.Value, *bool) {
+	p := reflect.New(w.typ)
+	v := p.Elem()
+	success := new(bool)
+	if w.result >= 0 {
+		success = reflect.ValueOf(out).Elem().Field(w.result).Addr().Interface().(*bool)
+	}
+	v.Field(0).Set(reflect.ValueOf(success))
+	v.Field(1).Set(reflect.ValueOf(errBody))
+	if len(w.fields) > 0 {
+		o := reflect.ValueOf(out).Elem()
+		for i, idx := range w.fields {
+			v.Field(i + 2).Set(o.Field(idx).Addr())
+		}
+	}
+	return p, success
+}
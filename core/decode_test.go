@@ -0,0 +1,209 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type decodeItem struct {
+	ID     string            `json:"id"`
+	Name   string            `json:"name"`
+	Price  int64             `json:"price_in_cents"`
+	Tags   []string          `json:"tags"`
+	Fields map[string]string `json:"fields"`
+}
+
+type decodeList struct {
+	Success bool         `json:"success"`
+	Data    []decodeItem `json:"data"`
+}
+
+func TestDecode(t *testing.T) {
+	ok := &ResponseMeta{Status: http.StatusOK}
+
+	var out decodeList
+	err := decode(ok, strings.NewReader(`{"meta":{"x":1},"success":true,"data":[{"id":"a","name":"A"}]}`), &out)
+	if err != nil || !out.Success || len(out.Data) != 1 || out.Data[0].ID != "a" {
+		t.Fatalf("decode = %+v, %v", out, err)
+	}
+
+	err = decode(ok, strings.NewReader(`{"success":false,"error":{"code":"NOT_FOUND","message":"gone"}}`), &out)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("error envelope on 200: got %v", err)
+	}
+
+	err = decode(&ResponseMeta{Status: http.StatusBadGateway}, strings.NewReader(`<html>bad gateway</html>`), &out)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != "HTTP_ERROR" || string(apiErr.Raw) != "<html>bad gateway</html>" {
+		t.Errorf("non-JSON 502: got %v", err)
+	}
+
+	var items []decodeItem
+	if err := decode(ok, strings.NewReader(`[{"id":"b"}]`), &items); err != nil || len(items) != 1 {
+		t.Errorf("top-level array: got %v, %v", items, err)
+	}
+
+	if err := decode(ok, strings.NewReader(""), &out); err != nil {
+		t.Errorf("empty body: %v", err)
+	}
+
+	var bare struct {
+		Data  decodeItem
+		Count int `json:",omitempty"`
+	}
+	err = decode(ok, strings.NewReader(`{"data":{"id":"c"},"count":2}`), &bare)
+	if err != nil || bare.Data.ID != "c" || bare.Count != 2 {
+		t.Errorf("struct without tags: got %+v, %v", bare, err)
+	}
+	err = decode(ok, strings.NewReader(`{"success":false,"error":{"code":"CONFLICT","message":"taken"}}`), &bare)
+	if !errors.Is(err, ErrConflict) {
+		t.Errorf("error envelope into a struct without success: got %v", err)
+	}
+	if err := decode(ok, strings.NewReader(`{"success":false,"error":{"code":"CONFLICT"}}`), nil); !errors.Is(err, ErrConflict) {
+		t.Errorf("error envelope with nil out: got %v", err)
+	}
+
+	type Inner struct{ ID string }
+	var embedded struct {
+		Inner
+		Name string `json:"name"`
+	}
+	if err := decode(ok, strings.NewReader(`{"ID":"d","name":"D"}`), &embedded); err != nil || embedded.ID != "d" || embedded.Name != "D" {
+		t.Errorf("embedded struct: got %+v, %v", embedded, err)
+	}
+}
+
+// failingReader returns its data and then err.
+type failingReader struct {
+	data string
+	err  error
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	if r.data == "" {
+		return 0, r.err
+	}
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
+
+func TestDecodeKeepsReadErrors(t *testing.T) {
+	var out decodeList
+	body := &failingReader{data: `{"success":true,"data":[{"id":"a"},`, err: context.DeadlineExceeded}
+	err := decode(&ResponseMeta{Status: http.StatusOK}, body, &out)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want it to wrap context.DeadlineExceeded", err)
+	}
+	if errorLabel(err) != "CANCELED" {
+		t.Errorf("errorLabel = %s", errorLabel(err))
+	}
+}
+
+func TestTimeoutWhileReadingBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"success":true,"data":[{"id":"a"},`))
+		w.(http.Flusher).Flush()
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer srv.Close()
+
+	c := New("key", "store", WithBaseURL(srv.URL))
+	var out decodeList
+	_, err := c.Do(context.Background(), http.MethodGet, "/products", nil, nil, &out, WithTimeout(50*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want it to wrap context.DeadlineExceeded", err)
+	}
+}
+
+func TestDecodeSizeLimit(t *testing.T) {
+	c := New("key", "store", WithMaxResponseBytes(16))
+	body := c.limitBody(strings.NewReader(`{"success":true,"data":[{"id":"abcdefghijklmnop"}]}`))
+	var out decodeList
+	if err := decode(&ResponseMeta{Status: http.StatusOK}, body, &out); !errors.Is(err, ErrResponseTooLarge) {
+		t.Fatalf("got %v, want ErrResponseTooLarge", err)
+	}
+}
+
+func listBody(n int) []byte {
+	var b bytes.Buffer
+	b.WriteString(`{"success":true,"data":[`)
+	for i := range n {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, `{"id":"prod_%d","name":"Product %d","price_in_cents":%d,"tags":["a","b","c"],"fields":{"k":"v"}}`, i, i, i*100)
+	}
+	b.WriteString(`],"pagination":{"page":1,"limit":1000,"total":1000,"total_pages":1}}`)
+	return b.Bytes()
+}
+
+// decodeReadAll is how responses were decoded before decode streamed them:
+// read the whole body, probe the envelope, then unmarshal again into out.
+func decodeReadAll(body io.Reader, out any) error {
+	raw, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	var probe envelope[json.RawMessage]
+	if err := json.Unmarshal(raw, &probe); err != nil {
+		return err
+	}
+	if !probe.Success {
+		return errors.New("unexpected error envelope")
+	}
+	return json.Unmarshal(raw, out)
+}
+
+func BenchmarkDecode(b *testing.B) {
+	body := listBody(1000)
+	meta := &ResponseMeta{Status: http.StatusOK}
+	b.SetBytes(int64(len(body)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		var out decodeList
+		if err := decode(meta, bytes.NewReader(body), &out); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkUnmarshal is the floor: the body already in memory, decoded once.
+func BenchmarkUnmarshal(b *testing.B) {
+	body := listBody(1000)
+	b.SetBytes(int64(len(body)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		var out decodeList
+		if err := json.Unmarshal(body, &out); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeReadAll(b *testing.B) {
+	body := listBody(1000)
+	b.SetBytes(int64(len(body)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		var out decodeList
+		if err := decodeReadAll(bytes.NewReader(body), &out); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package core
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+)
+
+// The enums below decode any value the API sends: known values are matched
+// case-insensitively, anything else is kept as is and reports Valid() ==
+// false, so a value added to the API later does not break decoding.
+
+type OrderStatus string
+
+const (
+	OrderPending   OrderStatus = "pending"
+	OrderCompleted OrderStatus = "completed"
+	OrderCanceled  OrderStatus = "canceled"
+	OrderRefunded  OrderStatus = "refunded"
+)
+
+var orderStatuses = []OrderStatus{OrderPending, OrderCompleted, OrderCanceled, OrderRefunded}
+
+func (v OrderStatus) Valid() bool { return slices.Contains(orderStatuses, v) }
+
+func (v *OrderStatus) UnmarshalJSON(b []byte) (err error) {
+	*v, err = unmarshalEnum(b, orderStatuses)
+	return err
+}
+
+type DeliveryType string
+
+const (
+	DeliveryFile    DeliveryType = "file"
+	DeliverySerials DeliveryType = "serials"
+	DeliveryService DeliveryType = "service"
+	DeliveryDynamic DeliveryType = "dynamic"
+)
+
+var deliveryTypes = []DeliveryType{DeliveryFile, DeliverySerials, DeliveryService, DeliveryDynamic}
+
+func (v DeliveryType) Valid() bool { return slices.Contains(deliveryTypes, v) }
+
+func (v *DeliveryType) UnmarshalJSON(b []byte) (err error) {
+	*v, err = unmarshalEnum(b, deliveryTypes)
+	return err
+}
+
+type PaymentMethod string
+
+const (
+	PaymentStripe  PaymentMethod = "stripe"
+	PaymentPayPal  PaymentMethod = "paypal"
+	PaymentCrypto  PaymentMethod = "crypto"
+	PaymentCashApp PaymentMethod = "cashapp"
+)
+
+var paymentMethods = []PaymentMethod{PaymentStripe, PaymentPayPal, PaymentCrypto, PaymentCashApp}
+
+func (v PaymentMethod) Valid() bool { return slices.Contains(paymentMethods, v) }
+
+func (v *PaymentMethod) UnmarshalJSON(b []byte) (err error) {
+	*v, err = unmarshalEnum(b, paymentMethods)
+	return err
+}
+
+type BlacklistType string
+
+const (
+	BlacklistEmail   BlacklistType = "email"
+	BlacklistIP      BlacklistType = "ip"
+	BlacklistCountry BlacklistType = "country"
+)
+
+var blacklistTypes = []BlacklistType{BlacklistEmail, BlacklistIP, BlacklistCountry}
+
+func (v BlacklistType) Valid() bool { return slices.Contains(blacklistTypes, v) }
+
+func (v *BlacklistType) UnmarshalJSON(b []byte) (err error) {
+	*v, err = unmarshalEnum(b, blacklistTypes)
+	return err
+}
+
+type TicketStatus string
+
+const (
+	TicketOpen    TicketStatus = "open"
+	TicketPending TicketStatus = "pending"
+	TicketClosed  TicketStatus = "closed"
+)
+
+var ticketStatuses = []TicketStatus{TicketOpen, TicketPending, TicketClosed}
+
+func (v TicketStatus) Valid() bool { return slices.Contains(ticketStatuses, v) }
+
+func (v *TicketStatus) UnmarshalJSON(b []byte) (err error) {
+	*v, err = unmarshalEnum(b, ticketStatuses)
+	return err
+}
+
+type TicketPriority string
+
+const (
+	PriorityLow    TicketPriority = "low"
+	PriorityMedium TicketPriority = "medium"
+	PriorityHigh   TicketPriority = "high"
+	PriorityUrgent TicketPriority = "urgent"
+)
+
+var ticketPriorities = []TicketPriority{PriorityLow, PriorityMedium, PriorityHigh, PriorityUrgent}
+
+func (v TicketPriority) Valid() bool { return slices.Contains(ticketPriorities, v) }
+
+func (v *TicketPriority) UnmarshalJSON(b []byte) (err error) {
+	*v, err = unmarshalEnum(b, ticketPriorities)
+	return err
+}
+
+type CouponType string
+
+const (
+	CouponPercentage CouponType = "percentage" // Value is a percentage, 1-100
+	CouponFixed      CouponType = "fixed"      // Value is an amount in cents
+)
+
+var couponTypes = []CouponType{CouponPercentage, CouponFixed}
+
+func (v CouponType) Valid() bool { return slices.Contains(couponTypes, v) }
+
+func (v *CouponType) UnmarshalJSON(b []byte) (err error) {
+	*v, err = unmarshalEnum(b, couponTypes)
+	return err
+}
+
+// unmarshalEnum maps b onto one of known. null decodes to "" and values that
+// are not strings keep their JSON text.
+func unmarshalEnum[T ~string](b []byte, known []T) (T, error) {
+	if string(b) == "null" {
+		return "", nil
+	}
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return T(b), nil
+	}
+	for _, k := range known {
+		if strings.EqualFold(s, string(k)) {
+			return k, nil
+		}
+	}
+	return T(s), nil
+}
@@ -1,22 +1,183 @@
 package core
 
-import "fmt"
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// Error classes matched by *APIError through errors.Is.
+var (
+	ErrNotFound     = errors.New("sellium: not found")
+	ErrUnauthorized = errors.New("sellium: unauthorized")
+	ErrForbidden    = errors.New("sellium: forbidden")
+	ErrRateLimited  = errors.New("sellium: rate limited")
+	ErrValidation   = errors.New("sellium: validation failed")
+	ErrConflict     = errors.New("sellium: conflict")
+	ErrServer       = errors.New("sellium: server error")
+)
 
 type APIErrorBody struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
+	Code    string          `json:"code"`
+	Message string          `json:"message"`
+	Details json.RawMessage `json:"details,omitempty"`
+	Fields  json.RawMessage `json:"fields,omitempty"`
+	Errors  json.RawMessage `json:"errors,omitempty"`
 }
 
 type APIError struct {
-	Status  int
-	Code    string
-	Message string
-	Raw     []byte
+	Status        int
+	Code          string
+	Message       string
+	Fields        []FieldError    // per-field validation errors, when the API sent them
+	Details       json.RawMessage // unparsed error details
+	RetryAfter    time.Duration   // from Retry-After or X-RateLimit-Reset, if sent
+	RequestID     string          // server-assigned request ID, quote it in support cases
+	CorrelationID string          // the ID attached with ContextWithCorrelationID
+	Raw           []byte
+}
+
+func newAPIError(meta *ResponseMeta, body *APIErrorBody, raw []byte) *APIError {
+	e := &APIError{
+		Status:        meta.Status,
+		Code:          body.Code,
+		Message:       body.Message,
+		RequestID:     meta.RequestID,
+		CorrelationID: meta.CorrelationID,
+		Raw:           raw,
+	}
+	e.RetryAfter, _ = retryAfter(meta.Headers)
+	for _, details := range []json.RawMessage{body.Details, body.Fields, body.Errors} {
+		if len(details) == 0 {
+			continue
+		}
+		if e.Details == nil {
+			e.Details = details
+		}
+		if e.Fields = parseFieldErrors(details); e.Fields != nil {
+			e.Details = details
+			break
+		}
+	}
+	return e
 }
 
 func (e *APIError) Error() string {
+	var msg string
 	if e.Code != "" {
-		return fmt.Sprintf("sellium API error (%d) %s: %s", e.Status, e.Code, e.Message)
+		msg = fmt.Sprintf("sellium API error (%d) %s: %s", e.Status, e.Code, e.Message)
+	} else {
+		msg = fmt.Sprintf("sellium API error (%d): %s", e.Status, e.Message)
+	}
+	if e.RequestID != "" {
+		msg += " [request_id=" + e.RequestID + "]"
+	}
+	if e.CorrelationID != "" {
+		msg += " [correlation_id=" + e.CorrelationID + "]"
+	}
+	return msg
+}
+
+// Is reports whether the error belongs to one of the Err* classes, based on
+// the HTTP status and, failing that, on the API error code.
+func (e *APIError) Is(target error) bool {
+	return target != nil && e.class() == target
+}
+
+func (e *APIError) class() error {
+	switch {
+	case e.Status == http.StatusNotFound:
+		return ErrNotFound
+	case e.Status == http.StatusUnauthorized:
+		return ErrUnauthorized
+	case e.Status == http.StatusForbidden:
+		return ErrForbidden
+	case e.Status == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case e.Status == http.StatusBadRequest || e.Status == http.StatusUnprocessableEntity:
+		return ErrValidation
+	case e.Status == http.StatusConflict:
+		return ErrConflict
+	case e.Status >= 500:
+		return ErrServer
+	}
+	return codeClasses[e.Code]
+}
+
+var codeClasses = map[string]error{
+	"NOT_FOUND":           ErrNotFound,
+	"UNAUTHORIZED":        ErrUnauthorized,
+	"INVALID_API_KEY":     ErrUnauthorized,
+	"FORBIDDEN":           ErrForbidden,
+	"RATE_LIMITED":        ErrRateLimited,
+	"RATE_LIMIT_EXCEEDED": ErrRateLimited,
+	"VALIDATION_ERROR":    ErrValidation,
+	"INVALID_REQUEST":     ErrValidation,
+	"CONFLICT":            ErrConflict,
+	"INTERNAL_ERROR":      ErrServer,
+}
+
+func (e *RateLimitError) Is(target error) bool { return target == ErrRateLimited }
+
+// IsRetryable reports whether repeating the request that produced err may
+// succeed: 429, 502, 503 and 504 responses, the client-side rate limiter and
+// transient network errors (timeouts, refused or reset connections and
+// connections closed early). TLS and certificate failures, unknown hosts,
+// bad URLs, context cancellation and ErrCircuitOpen are not retryable.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return retryableStatus(apiErr.Status)
+	}
+	var rlErr *RateLimitError
+	if errors.As(err, &rlErr) {
+		return true
+	}
+	return transientNetErr(err)
+}
+
+func transientNetErr(err error) bool {
+	var (
+		unknownAuthority x509.UnknownAuthorityError
+		hostname         x509.HostnameError
+		invalidCert      x509.CertificateInvalidError
+		verifyErr        *tls.CertificateVerificationError
+		recordErr        tls.RecordHeaderError
+	)
+	if errors.As(err, &unknownAuthority) || errors.As(err, &hostname) || errors.As(err, &invalidCert) ||
+		errors.As(err, &verifyErr) || errors.As(err, &recordErr) {
+		return false
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+	// *url.Error reports the Timeout of the error it wraps.
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// RetryAfter returns how long the API (or the client-side limiter) asked to
+// wait before retrying.
+func RetryAfter(err error) (time.Duration, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		return apiErr.RetryAfter, true
+	}
+	var rlErr *RateLimitError
+	if errors.As(err, &rlErr) {
+		return rlErr.RetryAfter, true
 	}
-	return fmt.Sprintf("sellium API error (%d): %s", e.Status, e.Message)
+	return 0, false
 }
@@ -0,0 +1,117 @@
+package core
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+type timeoutErr struct{}
+
+func (timeoutErr) Error() string   { return "i/o timeout" }
+func (timeoutErr) Timeout() bool   { return true }
+func (timeoutErr) Temporary() bool { return true }
+
+// countingTransport counts the requests that reach the network.
+type countingTransport struct {
+	n  int
+	rt http.RoundTripper
+}
+
+func (t *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	t.n++
+	return t.rt.RoundTrip(r)
+}
+
+func urlErr(err error) error { return &url.Error{Op: "Get", URL: "https://api.example.com", Err: err} }
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"503", &APIError{Status: http.StatusServiceUnavailable}, true},
+		{"400", &APIError{Status: http.StatusBadRequest}, false},
+		{"rate limiter", &RateLimitError{}, true},
+		{"canceled", urlErr(context.Canceled), false},
+		{"timeout", urlErr(timeoutErr{}), true},
+		{"eof", urlErr(io.EOF), true},
+		{"unexpected eof", urlErr(io.ErrUnexpectedEOF), true},
+		{"reset", urlErr(&net.OpError{Op: "read", Err: os.NewSyscallError("read", syscall.ECONNRESET)}), true},
+		{"refused", urlErr(&net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}), true},
+		{"unknown authority", urlErr(x509.UnknownAuthorityError{}), false},
+		{"hostname", urlErr(x509.HostnameError{Host: "api.example.com"}), false},
+		{"unknown host", urlErr(&net.OpError{Op: "dial", Err: &net.DNSError{Err: "no such host", Name: "nope", IsNotFound: true}}), false},
+		{"plain url error", urlErr(errors.New("unsupported protocol scheme \"ftp\"")), false},
+	}
+	for _, tt := range tests {
+		if got := IsRetryable(tt.err); got != tt.want {
+			t.Errorf("%s: IsRetryable(%v) = %v, want %v", tt.name, tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestRetrySkipsCertificateErrors(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+	srv.Config.ErrorLog = log.New(io.Discard, "", 0)
+
+	rt := &countingTransport{rt: http.DefaultTransport}
+	c := New("key", "store", WithBaseURL(srv.URL), WithHTTPClient(&http.Client{Transport: rt}),
+		WithRetry(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}))
+	_, err := c.Do(context.Background(), http.MethodGet, "/store", nil, nil, nil)
+	if err == nil {
+		t.Fatal("expected a certificate error")
+	}
+	if IsRetryable(err) {
+		t.Errorf("certificate error %v reported as retryable", err)
+	}
+	if rt.n != 1 {
+		t.Errorf("sent %d times, want 1", rt.n)
+	}
+}
+
+func TestRetrySkipsUnsupportedScheme(t *testing.T) {
+	rt := &countingTransport{rt: http.DefaultTransport}
+	c := New("key", "store", WithBaseURL("ftp://example.com"), WithHTTPClient(&http.Client{Transport: rt}),
+		WithRetry(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}))
+	_, err := c.Do(context.Background(), http.MethodGet, "/store", nil, nil, nil)
+	if err == nil || IsRetryable(err) {
+		t.Fatalf("err = %v, want a non-retryable error", err)
+	}
+	if rt.n != 1 {
+		t.Errorf("sent %d times, want 1", rt.n)
+	}
+}
+
+func TestRetryOnRefusedConnection(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	rt := &countingTransport{rt: http.DefaultTransport}
+	c := New("key", "store", WithBaseURL("http://"+addr), WithHTTPClient(&http.Client{Transport: rt}),
+		WithRetry(RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}))
+	_, err = c.Do(context.Background(), http.MethodGet, "/store", nil, nil, nil)
+	if !IsRetryable(err) {
+		t.Fatalf("refused connection %v not retryable", err)
+	}
+	if rt.n != 2 {
+		t.Errorf("sent %d times, want 2", rt.n)
+	}
+}
@@ -0,0 +1,124 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ExpvarMetrics is a Metrics implementation that publishes three expvar maps
+// keyed by "METHOD /route":
+//
+//	<prefix>.requests  request count per status, e.g. "GET /orders/{id} 200"
+//	<prefix>.errors    error count per error code, e.g. "GET /orders/{id} NOT_FOUND"
+//	<prefix>.latency   latency histogram in milliseconds
+type ExpvarMetrics struct {
+	requests *expvar.Map
+	errors   *expvar.Map
+	latency  *expvar.Map
+
+	mu sync.Mutex
+}
+
+// NewExpvarMetrics publishes the maps under prefix, reusing them if another
+// client already did.
+func NewExpvarMetrics(prefix string) *ExpvarMetrics {
+	return &ExpvarMetrics{
+		requests: publishedMap(prefix + ".requests"),
+		errors:   publishedMap(prefix + ".errors"),
+		latency:  publishedMap(prefix + ".latency"),
+	}
+}
+
+func publishedMap(name string) *expvar.Map {
+	if m, ok := expvar.Get(name).(*expvar.Map); ok {
+		return m
+	}
+	return expvar.NewMap(name)
+}
+
+func (m *ExpvarMetrics) ObserveRequest(method, route string, status int, d time.Duration, err error) {
+	key := method + " " + route
+	m.requests.Add(key+" "+strconv.Itoa(status), 1)
+	if err != nil {
+		m.errors.Add(key+" "+errorLabel(err), 1)
+	}
+
+	h, ok := m.latency.Get(key).(*latencyHistogram)
+	if !ok {
+		m.mu.Lock()
+		if h, ok = m.latency.Get(key).(*latencyHistogram); !ok {
+			h = &latencyHistogram{}
+			m.latency.Set(key, h)
+		}
+		m.mu.Unlock()
+	}
+	h.observe(d)
+}
+
+func errorLabel(err error) string {
+	var apiErr *APIError
+	var rlErr *RateLimitError
+	switch {
+	case errors.As(err, &apiErr) && apiErr.Code != "":
+		return apiErr.Code
+	case errors.As(err, &rlErr):
+		return "CLIENT_RATE_LIMITED"
+	case errors.Is(err, ErrCircuitOpen):
+		return "CIRCUIT_OPEN"
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return "CANCELED"
+	}
+	return "NETWORK_ERROR"
+}
+
+// upper bounds in milliseconds; the last bucket is +Inf
+var latencyBuckets = []float64{5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000}
+
+type latencyHistogram struct {
+	mu     sync.Mutex
+	count  int64
+	sumMs  float64
+	counts [12]int64
+}
+
+func (h *latencyHistogram) observe(d time.Duration) {
+	ms := float64(d) / float64(time.Millisecond)
+	i := 0
+	for i < len(latencyBuckets) && ms > latencyBuckets[i] {
+		i++
+	}
+	h.mu.Lock()
+	h.count++
+	h.sumMs += ms
+	h.counts[i]++
+	h.mu.Unlock()
+}
+
+// String renders cumulative buckets as JSON, as required by expvar.Var.
+func (h *latencyHistogram) String() string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `{"count":%d,"sum_ms":%.3f,"buckets":{`, h.count, h.sumMs)
+	var cum int64
+	for i, n := range h.counts {
+		cum += n
+		le := "+Inf"
+		if i < len(latencyBuckets) {
+			le = strconv.FormatFloat(latencyBuckets[i], 'f', -1, 64)
+		}
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, `"%s":%d`, le, cum)
+	}
+	b.WriteString("}}")
+	return b.String()
+}
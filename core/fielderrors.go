@@ -0,0 +1,149 @@
+package core
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FieldError is a validation failure for a single request field, e.g.
+// price_in_cents or code. Nested fields are joined with dots.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message"`
+}
+
+// FieldError returns the first validation error reported for field.
+func (e *APIError) FieldError(field string) (FieldError, bool) {
+	for _, fe := range e.Fields {
+		if fe.Field == field {
+			return fe, true
+		}
+	}
+	return FieldError{}, false
+}
+
+// parseFieldErrors understands the shapes validation details come in:
+//
+//	[{"field": "code", "code": "taken", "message": "..."}]
+//	[{"path": ["volume_discounts", 0, "price"], "message": "..."}]
+//	{"field": "price_in_cents", "code": "min", "message": "..."}
+//	{"code": "already taken"}
+//	{"code": ["already taken", "too short"]}
+//	{"fields": ...} or {"errors": ...} wrapping any of the above
+//
+// Anything else yields nil; the payload stays available in APIError.Details.
+func parseFieldErrors(raw json.RawMessage) []FieldError {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	var list []map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &list); err == nil {
+		var out []FieldError
+		for _, item := range list {
+			fe, ok := fieldErrorFromObject(item)
+			if !ok {
+				return nil
+			}
+			out = append(out, fe)
+		}
+		return out
+	}
+
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return nil
+	}
+	for _, wrapper := range []string{"fields", "errors", "field_errors"} {
+		if inner, ok := obj[wrapper]; ok && len(obj) == 1 {
+			return parseFieldErrors(inner)
+		}
+	}
+	if isFieldErrorObject(obj) {
+		if fe, ok := fieldErrorFromObject(obj); ok {
+			return []FieldError{fe}
+		}
+	}
+
+	names := make([]string, 0, len(obj))
+	for name := range obj {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var out []FieldError
+	for _, name := range names {
+		var msg string
+		if err := json.Unmarshal(obj[name], &msg); err == nil {
+			out = append(out, FieldError{Field: name, Message: msg})
+			continue
+		}
+		var msgs []string
+		if err := json.Unmarshal(obj[name], &msgs); err == nil {
+			for _, m := range msgs {
+				out = append(out, FieldError{Field: name, Message: m})
+			}
+			continue
+		}
+		return nil
+	}
+	return out
+}
+
+func fieldErrorFromObject(item map[string]json.RawMessage) (FieldError, bool) {
+	var fe FieldError
+	for _, key := range []string{"field", "param", "path"} {
+		if v, ok := item[key]; ok {
+			fe.Field = fieldPath(v)
+			break
+		}
+	}
+	_ = json.Unmarshal(item["code"], &fe.Code)
+	if err := json.Unmarshal(item["message"], &fe.Message); err != nil {
+		_ = json.Unmarshal(item["msg"], &fe.Message)
+	}
+	return fe, fe.Field != "" || fe.Message != ""
+}
+
+// isFieldErrorObject tells a single error such as {"field": "x", "message":
+// "..."} apart from a map of field names to messages.
+func isFieldErrorObject(obj map[string]json.RawMessage) bool {
+	_, msg := obj["message"]
+	if _, ok := obj["msg"]; ok {
+		msg = true
+	}
+	if !msg {
+		return false
+	}
+	for _, key := range []string{"field", "param", "path"} {
+		if v, ok := obj[key]; ok && fieldPath(v) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldPath accepts "a.b" as well as ["a", 0, "b"].
+func fieldPath(v json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(v, &s); err == nil {
+		return s
+	}
+	var parts []any
+	if err := json.Unmarshal(v, &parts); err != nil {
+		return ""
+	}
+	segs := make([]string, 0, len(parts))
+	for _, p := range parts {
+		switch t := p.(type) {
+		case string:
+			segs = append(segs, t)
+		case float64:
+			segs = append(segs, strconv.FormatFloat(t, 'f', -1, 64))
+		}
+	}
+	return strings.Join(segs, ".")
+}
@@ -0,0 +1,61 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseFieldErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want []FieldError
+	}{
+		{
+			name: "list",
+			raw:  `[{"field":"code","code":"taken","message":"already taken"}]`,
+			want: []FieldError{{Field: "code", Code: "taken", Message: "already taken"}},
+		},
+		{
+			name: "path",
+			raw:  `[{"path":["volume_discounts",0,"price"],"msg":"too low"}]`,
+			want: []FieldError{{Field: "volume_discounts.0.price", Message: "too low"}},
+		},
+		{
+			name: "single object",
+			raw:  `{"field":"price_in_cents","code":"min","message":"must be positive"}`,
+			want: []FieldError{{Field: "price_in_cents", Code: "min", Message: "must be positive"}},
+		},
+		{
+			name: "single object with param",
+			raw:  `{"param":"email","message":"invalid"}`,
+			want: []FieldError{{Field: "email", Message: "invalid"}},
+		},
+		{
+			name: "map",
+			raw:  `{"name":"required","code":["already taken","too short"]}`,
+			want: []FieldError{
+				{Field: "code", Message: "already taken"},
+				{Field: "code", Message: "too short"},
+				{Field: "name", Message: "required"},
+			},
+		},
+		{
+			name: "map with a message field",
+			raw:  `{"message":"too long","title":"required"}`,
+			want: []FieldError{{Field: "message", Message: "too long"}, {Field: "title", Message: "required"}},
+		},
+		{
+			name: "wrapped",
+			raw:  `{"errors":{"field":"price_in_cents","message":"must be positive"}}`,
+			want: []FieldError{{Field: "price_in_cents", Message: "must be positive"}},
+		},
+		{name: "unknown", raw: `{"limit":10}`},
+		{name: "empty", raw: ``},
+	}
+	for _, tt := range tests {
+		if got := parseFieldErrors([]byte(tt.raw)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
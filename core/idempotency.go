@@ -0,0 +1,37 @@
+package core
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+)
+
+type idempotencyKeyCtx struct{}
+
+// ContextWithIdempotencyKey makes Do send an Idempotency-Key header on requests
+// made with the returned context. An empty key generates a fresh one for every
+// call. The same key is reused across retries of a call, which makes a POST
+// carrying a key safe to retry.
+func ContextWithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyCtx{}, key)
+}
+
+func idempotencyKey(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(idempotencyKeyCtx{}).(string)
+	if !ok {
+		return "", false
+	}
+	if key == "" {
+		key = NewIdempotencyKey()
+	}
+	return key, true
+}
+
+// NewIdempotencyKey returns a random UUIDv4 suitable for the Idempotency-Key header.
+func NewIdempotencyKey() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
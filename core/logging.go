@@ -0,0 +1,245 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// WithLogger logs every call to Do. Successful calls are logged at Info,
+// failures at Warn; request and response bodies are added at Debug. The API
+// key is never logged, and email addresses (under any key, in the path, the
+// query or error text) and delivery secrets are masked unless
+// WithUnmaskedLogging is also given.
+func WithLogger(l *slog.Logger) Option { return func(c *Client) { c.logger = l } }
+
+func WithUnmaskedLogging() Option { return func(c *Client) { c.logUnmasked = true } }
+
+// LogValue keeps the API key out of logs when the client itself is logged.
+func (c *Client) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("base_url", c.baseURL),
+		slog.String("store_id", c.storeID),
+		slog.String("user_agent", c.userAgent),
+	)
+}
+
+func (c *Client) logging(next Handler) Handler {
+	return func(ctx context.Context, r *Request) (*ResponseMeta, error) {
+		start := time.Now()
+		meta, err := next(ctx, r)
+
+		level := slog.LevelInfo
+		if err != nil {
+			level = slog.LevelWarn
+		}
+		if !c.logger.Enabled(ctx, level) {
+			return meta, err
+		}
+
+		attrs := []slog.Attr{
+			slog.String("method", r.Method),
+			slog.String("path", c.maskPath(r.Path)),
+			slog.Duration("duration", time.Since(start)),
+		}
+		if len(r.Query) > 0 {
+			attrs = append(attrs, slog.String("query", c.maskQuery(r.Query)))
+		}
+		if id := CorrelationID(ctx); id != "" {
+			attrs = append(attrs, slog.String("correlation_id", id))
+		}
+		if meta != nil {
+			attrs = append(attrs, slog.Int("status", meta.Status), slog.Int("attempts", meta.Attempts))
+			if meta.RequestID != "" {
+				attrs = append(attrs, slog.String("request_id", meta.RequestID))
+			}
+			if meta.RateLimit != nil {
+				attrs = append(attrs, slog.Int("rate_limit_remaining", meta.RateLimit.Remaining))
+			}
+		}
+		if err != nil {
+			var apiErr *APIError
+			if errors.As(err, &apiErr) {
+				attrs = append(attrs, slog.String("error_code", apiErr.Code))
+			}
+			attrs = append(attrs, slog.String("error", c.maskError(err)))
+		}
+		if c.logger.Enabled(ctx, slog.LevelDebug) {
+			if r.Body != nil {
+				attrs = append(attrs, slog.String("request_body", c.maskBody(r.Body)))
+			}
+			if err == nil && r.Out != nil {
+				attrs = append(attrs, slog.String("response_body", c.maskBody(r.Out)))
+			}
+		}
+
+		c.logger.LogAttrs(ctx, level, "sellium request", attrs...)
+		return meta, err
+	}
+}
+
+// maskedKeys are always masked; email addresses are masked under any key.
+var maskedKeys = map[string]bool{
+	"email":            true,
+	"customer_email":   true,
+	"sender_email":     true,
+	"delivery_content": true,
+	"delivery_text":    true,
+	"serials":          true,
+}
+
+func (c *Client) maskPath(p string) string {
+	if c.logUnmasked {
+		return p
+	}
+	segs := strings.Split(p, "/")
+	for i, s := range segs {
+		if v, err := url.PathUnescape(s); err == nil && strings.Contains(v, "@") {
+			segs[i] = maskEmail(v)
+		}
+	}
+	return strings.Join(segs, "/")
+}
+
+func (c *Client) maskQuery(q url.Values) string {
+	masked := q
+	if !c.logUnmasked {
+		masked = maskValues(q)
+	}
+	if s, err := url.QueryUnescape(masked.Encode()); err == nil {
+		return s
+	}
+	return masked.Encode()
+}
+
+func maskValues(q url.Values) url.Values {
+	masked := url.Values{}
+	for k, vs := range q {
+		for _, v := range vs {
+			if maskedKeys[k] {
+				v = maskEmail(v)
+			} else {
+				v = maskEmails(v)
+			}
+			masked.Add(k, v)
+		}
+	}
+	return masked
+}
+
+func (c *Client) maskBody(v any) string {
+	switch v.(type) {
+	case EncodeFunc, io.Reader:
+		return "[streamed]"
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	if c.logUnmasked {
+		return string(b)
+	}
+	var doc any
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return ""
+	}
+	b, _ = json.Marshal(maskJSON(doc))
+	return string(b)
+}
+
+func maskJSON(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, e := range t {
+			if maskedKeys[k] {
+				t[k] = maskValue(e)
+				continue
+			}
+			t[k] = maskJSON(e)
+		}
+	case string:
+		return maskEmails(t)
+	case []any:
+		for i, e := range t {
+			t[i] = maskJSON(e)
+		}
+	}
+	return v
+}
+
+// maskValue masks everything under a key in maskedKeys.
+func maskValue(v any) any {
+	switch t := v.(type) {
+	case nil:
+		return nil
+	case string:
+		if strings.Contains(t, "@") {
+			return maskEmail(t)
+		}
+		if t == "" {
+			return t
+		}
+	case []any:
+		for i, e := range t {
+			t[i] = maskValue(e)
+		}
+		return t
+	}
+	return "[REDACTED]"
+}
+
+// maskError masks the request URL that transport errors repeat, percent
+// encoded, in their text.
+func (c *Client) maskError(err error) string {
+	s := err.Error()
+	if c.logUnmasked {
+		return s
+	}
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) && urlErr.URL != "" {
+		s = strings.ReplaceAll(s, urlErr.URL, c.maskURL(urlErr.URL))
+	}
+	return maskEmails(s)
+}
+
+func (c *Client) maskURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		if s, err := url.QueryUnescape(raw); err == nil {
+			return maskEmails(s)
+		}
+		return "[REDACTED]"
+	}
+	masked := u.Scheme + "://" + u.Host + c.maskPath(u.EscapedPath())
+	if u.RawQuery != "" {
+		masked += "?" + c.maskQuery(u.Query())
+	}
+	return masked
+}
+
+var emailPattern = regexp.MustCompile(`[^\s@"'<>,;:()\[\]]+@[^\s@"'<>,;:()\[\]]+\.[^\s@"'<>,;:()\[\]]+`)
+
+// maskEmails masks every email address found in s.
+func maskEmails(s string) string {
+	if !strings.Contains(s, "@") {
+		return s
+	}
+	return emailPattern.ReplaceAllStringFunc(s, maskEmail)
+}
+
+// maskEmail keeps the first character of the local part and the domain.
+func maskEmail(s string) string {
+	at := strings.LastIndex(s, "@")
+	if at <= 0 {
+		return "[REDACTED]"
+	}
+	_, n := utf8.DecodeRuneInString(s)
+	return s[:n] + "***" + s[at:]
+}
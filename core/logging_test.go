@@ -0,0 +1,126 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestLoggingMasksEmails(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"success":true,"data":{"type":"email","value":"bob@example.com","note":"ask bob@example.com"}}`))
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	c := New("key", "store", WithBaseURL(srv.URL), WithLogger(logger))
+
+	body := map[string]any{"type": "email", "value": "bob@example.com"}
+	query := url.Values{"search": {"bob@example.com"}}
+	var out map[string]any
+	if _, err := c.Do(context.Background(), http.MethodPost, "/blacklist/bob@example.com", query, body, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	logged := buf.String()
+	if strings.Contains(logged, "bob@example.com") {
+		t.Fatalf("email logged in clear text:\n%s", logged)
+	}
+	if !strings.Contains(logged, "b***@example.com") {
+		t.Fatalf("masked email missing from log:\n%s", logged)
+	}
+}
+
+func TestLoggingMasksTransportErrors(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	c := New("key", "store", WithBaseURL("http://"+addr), WithLogger(logger))
+
+	query := url.Values{"customer_email": {"bob@example.com"}}
+	if _, err := c.Do(context.Background(), http.MethodGet, "/orders", query, nil, nil); err == nil {
+		t.Fatal("expected a connection error")
+	}
+	if _, err := c.Do(context.Background(), http.MethodGet, "/customers/"+url.PathEscape("bob@example.com"), nil, nil, nil); err == nil {
+		t.Fatal("expected a connection error")
+	}
+
+	logged := buf.String()
+	for _, leak := range []string{"bob@example.com", "bob%40example.com"} {
+		if strings.Contains(logged, leak) {
+			t.Fatalf("email logged in clear text as %s:\n%s", leak, logged)
+		}
+	}
+	if n := strings.Count(logged, `connection refused`); n != 2 {
+		t.Fatalf("error text lost while masking:\n%s", logged)
+	}
+}
+
+func TestLoggingMasksDeliverySecrets(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"success":true,"data":{}}`))
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	c := New("key", "store", WithBaseURL(srv.URL), WithLogger(logger))
+
+	body := map[string]any{"name": "Key", "serials": []string{"AAAA-1111", "BBBB-2222"}, "delivery_text": "license: CCCC-3333"}
+	if _, err := c.Do(context.Background(), http.MethodPost, "/products", nil, body, nil); err != nil {
+		t.Fatal(err)
+	}
+	logged := buf.String()
+	for _, secret := range []string{"AAAA-1111", "BBBB-2222", "CCCC-3333"} {
+		if strings.Contains(logged, secret) {
+			t.Fatalf("%s logged in clear text:\n%s", secret, logged)
+		}
+	}
+}
+
+func TestLoggingUnmasked(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"success":true,"data":{}}`))
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	c := New("key", "store", WithBaseURL(srv.URL), WithLogger(logger), WithUnmaskedLogging())
+
+	query := url.Values{"search": {"bob@example.com"}}
+	if _, err := c.Do(context.Background(), http.MethodGet, "/blacklist", query, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "bob@example.com") {
+		t.Fatalf("WithUnmaskedLogging masked the query:\n%s", buf.String())
+	}
+}
+
+func TestMaskEmails(t *testing.T) {
+	tests := map[string]string{
+		"bob@example.com":                "b***@example.com",
+		"contact bob@example.com today":  "contact b***@example.com today",
+		"<bob@example.com>, al@site.org": "<b***@example.com>, a***@site.org",
+		"no email here":                  "no email here",
+		"@handle":                        "@handle",
+	}
+	for in, want := range tests {
+		if got := maskEmails(in); got != want {
+			t.Errorf("maskEmails(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
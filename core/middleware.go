@@ -0,0 +1,46 @@
+package core
+
+import (
+	"context"
+	"net/url"
+)
+
+// Request is a single Do call as seen by middleware. Body is the typed request
+// value before encoding and Out the value the response is decoded into.
+type Request struct {
+	Method string
+	Path   string
+	Route  string // Path with IDs replaced, see NormalizeRoute
+	Query  url.Values
+	Body   any
+	Out    any
+
+	call callOptions
+}
+
+// Handler performs a request. API failures are returned as *APIError.
+type Handler func(ctx context.Context, req *Request) (*ResponseMeta, error)
+
+// Middleware wraps a Handler. Middleware registered first runs outermost.
+type Middleware func(next Handler) Handler
+
+func WithMiddleware(mw ...Middleware) Option {
+	return func(c *Client) { c.middleware = append(c.middleware, mw...) }
+}
+
+func (c *Client) handler() Handler {
+	h := Handler(c.do)
+	if c.logger != nil {
+		h = c.logging(h)
+	}
+	if c.metrics != nil {
+		h = c.measuring(h)
+	}
+	if c.tracer != nil {
+		h = c.tracing(h)
+	}
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		h = c.middleware[i](h)
+	}
+	return h
+}
@@ -12,24 +12,24 @@ type Socials struct {
 }
 
 type Store struct {
-	ID           string  `json:"id"`
-	Name         string  `json:"name"`
-	Slug         string  `json:"slug"`
-	Description  string  `json:"description,omitempty"`
-	LogoURL      string  `json:"logo_url,omitempty"`
-	CustomDomain string  `json:"custom_domain,omitempty"`
-	ThemeColor   string  `json:"theme_color,omitempty"`
-	SupportEmail string  `json:"support_email,omitempty"`
-	IsActive     bool    `json:"is_active"`
-	CreatedAt    string  `json:"created_at"`
-	UpdatedAt    string  `json:"updated_at"`
-	URL          string  `json:"url,omitempty"`
-	Socials      Socials `json:"socials,omitempty"`
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	Slug         string    `json:"slug"`
+	Description  string    `json:"description,omitempty"`
+	LogoURL      string    `json:"logo_url,omitempty"`
+	CustomDomain string    `json:"custom_domain,omitempty"`
+	ThemeColor   string    `json:"theme_color,omitempty"`
+	SupportEmail string    `json:"support_email,omitempty"`
+	IsActive     bool      `json:"is_active"`
+	CreatedAt    Timestamp `json:"created_at"`
+	UpdatedAt    Timestamp `json:"updated_at"`
+	URL          string    `json:"url,omitempty"`
+	Socials      Socials   `json:"socials,omitempty"`
 }
 
 type StoreStats struct {
 	TotalSales        int     `json:"total_sales"`
-	TotalRevenueCents int     `json:"total_revenue_cents"`
+	TotalRevenueCents Money   `json:"total_revenue_cents"`
 	TotalReviews      int     `json:"total_reviews"`
 	AverageRating     float64 `json:"average_rating"`
 	ProductCount      int     `json:"product_count"`
@@ -37,14 +37,14 @@ type StoreStats struct {
 }
 
 type Product struct {
-	ID            string `json:"id"`
-	Name          string `json:"name"`
-	Description   string `json:"description,omitempty"`
-	ImageURL      string `json:"image_url,omitempty"`
-	PriceInCents  int    `json:"price_in_cents"`
-	IsActive      bool   `json:"is_active"`
-	StockQuantity int    `json:"stock_quantity"`
-	DeliveryType  string `json:"delivery_type"`
+	ID            string       `json:"id"`
+	Name          string       `json:"name"`
+	Description   string       `json:"description,omitempty"`
+	ImageURL      string       `json:"image_url,omitempty"`
+	PriceInCents  Money        `json:"price_in_cents"`
+	IsActive      bool         `json:"is_active"`
+	StockQuantity int          `json:"stock_quantity"`
+	DeliveryType  DeliveryType `json:"delivery_type"`
 
 	MinimumQuantity int    `json:"minimum_quantity,omitempty"`
 	MaximumQuantity int    `json:"maximum_quantity,omitempty"`
@@ -78,40 +78,40 @@ type Product struct {
 
 	AvailableStock int `json:"available_stock,omitempty"`
 
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
+	CreatedAt Timestamp `json:"created_at"`
+	UpdatedAt Timestamp `json:"updated_at"`
 }
 
 type CouponAnalytics struct {
-	TotalUses         int  `json:"total_uses"`
-	TotalRevenueCents int  `json:"total_revenue_cents"`
-	RemainingUses     int  `json:"remaining_uses"`
-	IsExpired         bool `json:"is_expired"`
+	TotalUses         int   `json:"total_uses"`
+	TotalRevenueCents Money `json:"total_revenue_cents"`
+	RemainingUses     int   `json:"remaining_uses"`
+	IsExpired         bool  `json:"is_expired"`
 }
 
 type Coupon struct {
-	ID              string  `json:"id"`
-	Code            string  `json:"code"`
-	Type            string  `json:"type"`
-	Value           int     `json:"value"`
-	MinimumPurchase *int    `json:"minimum_purchase,omitempty"`
-	MaximumUses     *int    `json:"maximum_uses,omitempty"`
-	UsesCount       int     `json:"uses_count"`
-	IsActive        bool    `json:"is_active"`
-	ExpiresAt       *string `json:"expires_at,omitempty"`
-	CreatedAt       string  `json:"created_at"`
-	UpdatedAt       string  `json:"updated_at"`
+	ID              string     `json:"id"`
+	Code            string     `json:"code"`
+	Type            CouponType `json:"type"`
+	Value           int        `json:"value"`
+	MinimumPurchase *int       `json:"minimum_purchase,omitempty"`
+	MaximumUses     *int       `json:"maximum_uses,omitempty"`
+	UsesCount       int        `json:"uses_count"`
+	IsActive        bool       `json:"is_active"`
+	ExpiresAt       *Timestamp `json:"expires_at,omitempty"`
+	CreatedAt       Timestamp  `json:"created_at"`
+	UpdatedAt       Timestamp  `json:"updated_at"`
 
 	Analytics *CouponAnalytics `json:"analytics,omitempty"`
 }
 
 type OrderProductMini struct {
-	ID           string `json:"id"`
-	Name         string `json:"name"`
-	PriceInCents int    `json:"price_in_cents"`
-	DeliveryType string `json:"delivery_type,omitempty"`
-	ImageURL     string `json:"image_url,omitempty"`
-	ProductName  string `json:"product_name,omitempty"`
+	ID           string       `json:"id"`
+	Name         string       `json:"name"`
+	PriceInCents Money        `json:"price_in_cents"`
+	DeliveryType DeliveryType `json:"delivery_type,omitempty"`
+	ImageURL     string       `json:"image_url,omitempty"`
+	ProductName  string       `json:"product_name,omitempty"`
 }
 
 type Order struct {
@@ -119,13 +119,13 @@ type Order struct {
 	CustomerEmail string `json:"customer_email"`
 	CustomerName  string `json:"customer_name,omitempty"`
 
-	Status        string `json:"status"`
-	AmountInCents int    `json:"amount_in_cents"`
-	Quantity      int    `json:"quantity"`
+	Status        OrderStatus `json:"status"`
+	AmountInCents Money       `json:"amount_in_cents"`
+	Quantity      int         `json:"quantity"`
 
-	PaymentMethod   string `json:"payment_method,omitempty"`
-	PaymentVerified bool   `json:"payment_verified,omitempty"`
-	Delivered       bool   `json:"delivered,omitempty"`
+	PaymentMethod   PaymentMethod `json:"payment_method,omitempty"`
+	PaymentVerified bool          `json:"payment_verified,omitempty"`
+	Delivered       bool          `json:"delivered,omitempty"`
 
 	CheckoutURL     string `json:"checkout_url,omitempty"`
 	TransactionID   string `json:"transaction_id,omitempty"`
@@ -144,7 +144,7 @@ type Order struct {
 
 	CustomFields json.RawMessage `json:"custom_fields,omitempty"`
 
-	CreatedAt string `json:"created_at"`
+	CreatedAt Timestamp `json:"created_at"`
 
 	Product OrderProductMini `json:"product"`
 }
@@ -158,14 +158,14 @@ type Pagination struct {
 }
 
 type CustomerRow struct {
-	Email               string `json:"email"`
-	Name                string `json:"name,omitempty"`
-	TotalOrders         int    `json:"total_orders"`
-	CompletedOrders     int    `json:"completed_orders"`
-	TotalSpentCents     int    `json:"total_spent_cents"`
-	TotalSpentFormatted string `json:"total_spent_formatted"`
-	FirstOrderAt        string `json:"first_order_at,omitempty"`
-	LastOrderAt         string `json:"last_order_at,omitempty"`
+	Email               string     `json:"email"`
+	Name                string     `json:"name,omitempty"`
+	TotalOrders         int        `json:"total_orders"`
+	CompletedOrders     int        `json:"completed_orders"`
+	TotalSpentCents     Money      `json:"total_spent_cents"`
+	TotalSpentFormatted string     `json:"total_spent_formatted"`
+	FirstOrderAt        *Timestamp `json:"first_order_at,omitempty"`
+	LastOrderAt         *Timestamp `json:"last_order_at,omitempty"`
 }
 
 type CustomerStats struct {
@@ -174,9 +174,9 @@ type CustomerStats struct {
 	PendingOrders              int    `json:"pending_orders"`
 	CanceledOrders             int    `json:"canceled_orders"`
 	RefundedOrders             int    `json:"refunded_orders"`
-	TotalSpentCents            int    `json:"total_spent_cents"`
+	TotalSpentCents            Money  `json:"total_spent_cents"`
 	TotalSpentFormatted        string `json:"total_spent_formatted"`
-	AverageOrderValueCents     int    `json:"average_order_value_cents"`
+	AverageOrderValueCents     Money  `json:"average_order_value_cents"`
 	AverageOrderValueFormatted string `json:"average_order_value_formatted"`
 }
 
@@ -184,7 +184,7 @@ type CustomerTopProduct struct {
 	ProductID           string `json:"product_id"`
 	ProductName         string `json:"product_name"`
 	QuantityPurchased   int    `json:"quantity_purchased"`
-	TotalSpentCents     int    `json:"total_spent_cents"`
+	TotalSpentCents     Money  `json:"total_spent_cents"`
 	TotalSpentFormatted string `json:"total_spent_formatted"`
 }
 
@@ -192,45 +192,45 @@ type CustomerDetail struct {
 	Email              string               `json:"email"`
 	Name               string               `json:"name,omitempty"`
 	Stats              CustomerStats        `json:"stats"`
-	FirstOrderAt       string               `json:"first_order_at,omitempty"`
-	LastOrderAt        string               `json:"last_order_at,omitempty"`
-	PaymentMethodsUsed []string             `json:"payment_methods_used,omitempty"`
+	FirstOrderAt       *Timestamp           `json:"first_order_at,omitempty"`
+	LastOrderAt        *Timestamp           `json:"last_order_at,omitempty"`
+	PaymentMethodsUsed []PaymentMethod      `json:"payment_methods_used,omitempty"`
 	TopProducts        []CustomerTopProduct `json:"top_products,omitempty"`
 }
 
 type CustomerRecentOrder struct {
-	ID              string `json:"id"`
-	Status          string `json:"status"`
-	AmountInCents   int    `json:"amount_in_cents"`
-	AmountFormatted string `json:"amount_formatted,omitempty"`
-	Quantity        int    `json:"quantity"`
-	PaymentMethod   string `json:"payment_method,omitempty"`
+	ID              string        `json:"id"`
+	Status          OrderStatus   `json:"status"`
+	AmountInCents   Money         `json:"amount_in_cents"`
+	AmountFormatted string        `json:"amount_formatted,omitempty"`
+	Quantity        int           `json:"quantity"`
+	PaymentMethod   PaymentMethod `json:"payment_method,omitempty"`
 	Product         struct {
 		ID   string `json:"id"`
 		Name string `json:"name"`
 	} `json:"product"`
-	CreatedAt string `json:"created_at"`
+	CreatedAt Timestamp `json:"created_at"`
 }
 
 type Feedback struct {
-	ID            string  `json:"id"`
-	CustomerEmail string  `json:"customer_email"`
-	CustomerName  string  `json:"customer_name,omitempty"`
-	Message       string  `json:"message"`
-	Response      *string `json:"response,omitempty"`
-	Rating        int     `json:"rating"`
-	IsVisible     bool    `json:"is_visible"`
-	RespondedAt   *string `json:"responded_at,omitempty"`
-	CreatedAt     string  `json:"created_at"`
-	UpdatedAt     *string `json:"updated_at,omitempty"`
-	OrderID       string  `json:"order_id,omitempty"`
+	ID            string     `json:"id"`
+	CustomerEmail string     `json:"customer_email"`
+	CustomerName  string     `json:"customer_name,omitempty"`
+	Message       string     `json:"message"`
+	Response      *string    `json:"response,omitempty"`
+	Rating        int        `json:"rating"`
+	IsVisible     bool       `json:"is_visible"`
+	RespondedAt   *Timestamp `json:"responded_at,omitempty"`
+	CreatedAt     Timestamp  `json:"created_at"`
+	UpdatedAt     *Timestamp `json:"updated_at,omitempty"`
+	OrderID       string     `json:"order_id,omitempty"`
 
 	// single feedback includes order/product info
 	Order *struct {
-		ID            string `json:"id"`
-		AmountInCents int    `json:"amount_in_cents"`
-		Status        string `json:"status"`
-		CreatedAt     string `json:"created_at"`
+		ID            string      `json:"id"`
+		AmountInCents Money       `json:"amount_in_cents"`
+		Status        OrderStatus `json:"status"`
+		CreatedAt     Timestamp   `json:"created_at"`
 		Product       struct {
 			ID   string `json:"id"`
 			Name string `json:"name"`
@@ -239,60 +239,60 @@ type Feedback struct {
 }
 
 type TicketOrderSummary struct {
-	ID            string `json:"id"`
-	AmountInCents int    `json:"amount_in_cents"`
-	Status        string `json:"status"`
-	ProductName   string `json:"product_name,omitempty"`
+	ID            string      `json:"id"`
+	AmountInCents Money       `json:"amount_in_cents"`
+	Status        OrderStatus `json:"status"`
+	ProductName   string      `json:"product_name,omitempty"`
 }
 
 type Ticket struct {
 	ID            string              `json:"id"`
 	Subject       string              `json:"subject"`
-	Status        string              `json:"status"`
-	Priority      string              `json:"priority"`
+	Status        TicketStatus        `json:"status"`
+	Priority      TicketPriority      `json:"priority"`
 	CustomerEmail string              `json:"customer_email"`
 	CustomerName  string              `json:"customer_name,omitempty"`
 	MessageCount  int                 `json:"message_count,omitempty"`
 	Order         *TicketOrderSummary `json:"order,omitempty"`
-	CreatedAt     string              `json:"created_at"`
-	UpdatedAt     string              `json:"updated_at"`
-	ClosedAt      *string             `json:"closed_at,omitempty"`
+	CreatedAt     Timestamp           `json:"created_at"`
+	UpdatedAt     Timestamp           `json:"updated_at"`
+	ClosedAt      *Timestamp          `json:"closed_at,omitempty"`
 }
 
 type TicketMessage struct {
-	ID          string `json:"id"`
-	TicketID    string `json:"ticket_id,omitempty"`
-	Message     string `json:"message"`
-	SenderType  string `json:"sender_type"`
-	SenderEmail string `json:"sender_email"`
-	CreatedAt   string `json:"created_at"`
+	ID          string    `json:"id"`
+	TicketID    string    `json:"ticket_id,omitempty"`
+	Message     string    `json:"message"`
+	SenderType  string    `json:"sender_type"`
+	SenderEmail string    `json:"sender_email"`
+	CreatedAt   Timestamp `json:"created_at"`
 }
 
 type BlacklistEntry struct {
-	ID        string `json:"id"`
-	Type      string `json:"type"`
-	Value     string `json:"value"`
-	Reason    string `json:"reason,omitempty"`
-	CreatedAt string `json:"created_at"`
+	ID        string        `json:"id"`
+	Type      BlacklistType `json:"type"`
+	Value     string        `json:"value"`
+	Reason    string        `json:"reason,omitempty"`
+	CreatedAt Timestamp     `json:"created_at"`
 }
 
 type Group struct {
-	ID           string  `json:"id"`
-	Name         string  `json:"name"`
-	Description  string  `json:"description,omitempty"`
-	ImageURL     *string `json:"image_url,omitempty"`
-	DisplayOrder int     `json:"display_order"`
-	IsActive     bool    `json:"is_active"`
-	ProductCount int     `json:"product_count"`
-	CreatedAt    string  `json:"created_at"`
-	UpdatedAt    string  `json:"updated_at"`
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	Description  string    `json:"description,omitempty"`
+	ImageURL     *string   `json:"image_url,omitempty"`
+	DisplayOrder int       `json:"display_order"`
+	IsActive     bool      `json:"is_active"`
+	ProductCount int       `json:"product_count"`
+	CreatedAt    Timestamp `json:"created_at"`
+	UpdatedAt    Timestamp `json:"updated_at"`
 }
 
 type GroupProductMini struct {
-	ID            string `json:"id"`
-	Name          string `json:"name"`
-	PriceInCents  int    `json:"price_in_cents"`
-	IsActive      bool   `json:"is_active"`
-	StockQuantity int    `json:"stock_quantity"`
-	CreatedAt     string `json:"created_at"`
+	ID            string    `json:"id"`
+	Name          string    `json:"name"`
+	PriceInCents  Money     `json:"price_in_cents"`
+	IsActive      bool      `json:"is_active"`
+	StockQuantity int       `json:"stock_quantity"`
+	CreatedAt     Timestamp `json:"created_at"`
 }
@@ -0,0 +1,282 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	ErrCurrencyMismatch = errors.New("sellium: money amounts have different currencies")
+	ErrMoneyOverflow    = errors.New("sellium: money amount out of range")
+)
+
+// Money is an amount in the minor unit of a currency, e.g. cents. The API
+// sends amounts as bare integers, so Money marshals to and from a JSON
+// integer and a decoded value has no Currency until In sets one. Arithmetic
+// treats an empty Currency as matching any other.
+type Money struct {
+	Cents    int64
+	Currency string // ISO 4217 code such as "USD"
+}
+
+func NewMoney(cents int64, currency string) Money {
+	return Money{Cents: cents, Currency: strings.ToUpper(currency)}
+}
+
+// In returns m with its currency set to currency.
+func (m Money) In(currency string) Money { return NewMoney(m.Cents, currency) }
+
+func (m Money) IsZero() bool     { return m.Cents == 0 }
+func (m Money) IsNegative() bool { return m.Cents < 0 }
+
+func (m Money) Add(o Money) (Money, error) {
+	cur, err := m.currency(o)
+	if err != nil {
+		return Money{}, err
+	}
+	if (o.Cents > 0 && m.Cents > math.MaxInt64-o.Cents) || (o.Cents < 0 && m.Cents < math.MinInt64-o.Cents) {
+		return Money{}, ErrMoneyOverflow
+	}
+	return Money{Cents: m.Cents + o.Cents, Currency: cur}, nil
+}
+
+func (m Money) Sub(o Money) (Money, error) {
+	if o.Cents == math.MinInt64 {
+		return Money{}, ErrMoneyOverflow
+	}
+	return m.Add(Money{Cents: -o.Cents, Currency: o.Currency})
+}
+
+// Mul multiplies m by a quantity, e.g. a unit price by the number of units.
+func (m Money) Mul(n int64) (Money, error) {
+	if m.Cents != 0 && n != 0 {
+		p := m.Cents * n
+		if p/n != m.Cents || (m.Cents == -1 && n == math.MinInt64) || (n == -1 && m.Cents == math.MinInt64) {
+			return Money{}, ErrMoneyOverflow
+		}
+		return Money{Cents: p, Currency: m.Currency}, nil
+	}
+	return Money{Currency: m.Currency}, nil
+}
+
+// Cmp returns -1, 0 or +1 depending on whether m is less than, equal to or
+// greater than o.
+func (m Money) Cmp(o Money) (int, error) {
+	if _, err := m.currency(o); err != nil {
+		return 0, err
+	}
+	switch {
+	case m.Cents < o.Cents:
+		return -1, nil
+	case m.Cents > o.Cents:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// SumMoney adds up amounts of one currency.
+func SumMoney(amounts ...Money) (Money, error) {
+	var total Money
+	for _, a := range amounts {
+		var err error
+		if total, err = total.Add(a); err != nil {
+			return Money{}, err
+		}
+	}
+	return total, nil
+}
+
+func (m Money) currency(o Money) (string, error) {
+	switch {
+	case m.Currency == "":
+		return o.Currency, nil
+	case o.Currency == "" || strings.EqualFold(m.Currency, o.Currency):
+		return m.Currency, nil
+	}
+	return "", fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, o.Currency)
+}
+
+func (m Money) MarshalJSON() ([]byte, error) { return strconv.AppendInt(nil, m.Cents, 10), nil }
+
+// UnmarshalJSON accepts an integer, an integer in a string, or null. The
+// currency is left as it was.
+func (m *Money) UnmarshalJSON(b []byte) error {
+	s := string(b)
+	if s == "null" {
+		return nil
+	}
+	if len(s) > 1 && s[0] == '"' {
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+	}
+	cents, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("sellium: cannot parse %s as an amount in cents", b)
+	}
+	m.Cents = cents
+	return nil
+}
+
+// String formats m for the "en" locale, e.g. "$1,234.56".
+func (m Money) String() string { return m.Format("en") }
+
+// moneyLocale describes how a language writes amounts.
+type moneyLocale struct {
+	group, decimal string
+	suffix         bool   // symbol after the number
+	space          string // between number and symbol
+}
+
+var moneyLocales = map[string]moneyLocale{
+	"en": {group: ",", decimal: "."},
+	"ja": {group: ",", decimal: "."},
+	"zh": {group: ",", decimal: "."},
+	"nl": {group: ".", decimal: ",", space: "\u00a0"},
+	"de": {group: ".", decimal: ",", suffix: true, space: "\u00a0"},
+	"es": {group: ".", decimal: ",", suffix: true, space: "\u00a0"},
+	"it": {group: ".", decimal: ",", suffix: true, space: "\u00a0"},
+	"pt": {group: ".", decimal: ",", suffix: true, space: "\u00a0"},
+	"fr": {group: "\u202f", decimal: ",", suffix: true, space: "\u00a0"},
+	"sv": {group: "\u00a0", decimal: ",", suffix: true, space: "\u00a0"},
+	"pl": {group: "\u00a0", decimal: ",", suffix: true, space: "\u00a0"},
+}
+
+// lookupLocale matches the language of a BCP 47 tag such as "de-AT",
+// falling back to English.
+func lookupLocale(tag string) moneyLocale {
+	lang, _, _ := strings.Cut(strings.ToLower(strings.ReplaceAll(tag, "_", "-")), "-")
+	if l, ok := moneyLocales[lang]; ok {
+		return l
+	}
+	return moneyLocales["en"]
+}
+
+var currencySymbols = map[string]string{
+	"USD": "$", "EUR": "€", "GBP": "£", "JPY": "¥", "CNY": "CN¥", "INR": "₹",
+	"CAD": "CA$", "AUD": "A$", "NZD": "NZ$", "BRL": "R$", "KRW": "₩", "MXN": "MX$",
+	"PLN": "zł", "SEK": "kr", "NOK": "kr", "DKK": "kr", "CHF": "CHF", "TRY": "₺",
+}
+
+// minorDigits is the number of decimals of a currency's minor unit.
+func minorDigits(currency string) int {
+	switch strings.ToUpper(currency) {
+	case "JPY", "KRW", "VND", "CLP", "ISK", "UGX", "XAF", "XOF", "PYG", "RWF":
+		return 0
+	case "BHD", "KWD", "OMR", "JOD", "TND", "IQD", "LYD":
+		return 3
+	}
+	return 2
+}
+
+// Format writes m the way the language of locale (a BCP 47 tag such as
+// "en-US" or "de") writes amounts, e.g. "$1,234.56" or "1.234,56 €".
+// Unknown languages use English conventions, and unknown currencies their
+// ISO code as the symbol.
+func (m Money) Format(locale string) string {
+	l := lookupLocale(locale)
+	digits := minorDigits(m.Currency)
+
+	abs := uint64(m.Cents)
+	if m.Cents < 0 {
+		abs = uint64(-(m.Cents + 1)) + 1 // avoids overflowing on MinInt64
+	}
+	unit := uint64(math.Pow10(digits))
+	whole := strconv.FormatUint(abs/unit, 10)
+
+	var b strings.Builder
+	for i, r := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteString(l.group)
+		}
+		b.WriteRune(r)
+	}
+	if digits > 0 {
+		b.WriteString(l.decimal)
+		frac := strconv.FormatUint(abs%unit, 10)
+		b.WriteString(strings.Repeat("0", digits-len(frac)) + frac)
+	}
+	number := b.String()
+
+	symbol := m.Currency
+	if s, ok := currencySymbols[symbol]; ok {
+		symbol = s
+	}
+	sign := ""
+	if m.Cents < 0 {
+		sign = "-"
+	}
+	switch {
+	case symbol == "":
+		return sign + number
+	case l.suffix:
+		return sign + number + l.space + symbol
+	case len(symbol) > 1 && symbol == m.Currency: // a code like "CHF" reads better spaced
+		return sign + symbol + " " + number
+	}
+	return sign + symbol + l.space + number
+}
+
+// ParseMoney reads an amount written for locale, as produced by Format or the
+// API's *Formatted fields. Currency symbols, codes and spacing are ignored;
+// the result takes the given currency, whose minor unit bounds the number of
+// decimals allowed.
+func ParseMoney(s, currency, locale string) (Money, error) {
+	l := lookupLocale(locale)
+	digits := minorDigits(currency)
+	fail := func() (Money, error) {
+		return Money{}, fmt.Errorf("sellium: cannot parse %q as an amount in %s", s, strings.ToUpper(currency))
+	}
+
+	var whole, frac strings.Builder
+	negative, inFrac, fracDone, seenDigit := false, false, false, false
+	for _, r := range s {
+		isDigit := r >= '0' && r <= '9'
+		if inFrac && !isDigit {
+			fracDone = true // only a symbol or code may follow the decimals
+		}
+		switch {
+		case isDigit:
+			if fracDone {
+				return fail()
+			}
+			seenDigit = true
+			if inFrac {
+				frac.WriteRune(r)
+			} else {
+				whole.WriteRune(r)
+			}
+		case string(r) == l.decimal && !inFrac:
+			inFrac = true
+		case (string(r) == l.group && !inFrac) || unicode.IsSpace(r):
+		case r == '-' || r == '−' || r == '(':
+			if seenDigit || negative {
+				return fail()
+			}
+			negative = true
+		case r == ')':
+		case unicode.IsLetter(r) || unicode.Is(unicode.Sc, r):
+			// currency symbol or code
+		default:
+			return fail()
+		}
+	}
+	if !seenDigit || frac.Len() > digits {
+		return fail()
+	}
+
+	number := whole.String() + frac.String() + strings.Repeat("0", digits-frac.Len())
+	if negative {
+		number = "-" + number
+	}
+	cents, err := strconv.ParseInt(number, 10, 64)
+	if err != nil {
+		return Money{}, ErrMoneyOverflow
+	}
+	return NewMoney(cents, currency), nil
+}
@@ -0,0 +1,102 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestMoneyArithmetic(t *testing.T) {
+	a, b := NewMoney(1050, "usd"), NewMoney(250, "USD")
+	if sum, err := a.Add(b); err != nil || sum != NewMoney(1300, "USD") {
+		t.Errorf("Add = %v, %v", sum, err)
+	}
+	if diff, err := b.Sub(a); err != nil || diff.Cents != -800 {
+		t.Errorf("Sub = %v, %v", diff, err)
+	}
+	if p, err := b.Mul(3); err != nil || p.Cents != 750 {
+		t.Errorf("Mul = %v, %v", p, err)
+	}
+	if c, err := a.Cmp(b); err != nil || c != 1 {
+		t.Errorf("Cmp = %d, %v", c, err)
+	}
+	if sum, err := SumMoney(a, b, Money{Cents: 1}); err != nil || sum != NewMoney(1301, "USD") {
+		t.Errorf("SumMoney = %v, %v", sum, err)
+	}
+
+	if _, err := a.Add(NewMoney(1, "EUR")); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Add across currencies = %v", err)
+	}
+	top := Money{Cents: math.MaxInt64}
+	if _, err := top.Add(Money{Cents: 1}); !errors.Is(err, ErrMoneyOverflow) {
+		t.Errorf("Add overflow = %v", err)
+	}
+	if _, err := (Money{}).Sub(Money{Cents: math.MinInt64}); !errors.Is(err, ErrMoneyOverflow) {
+		t.Errorf("Sub overflow = %v", err)
+	}
+	if _, err := top.Mul(2); !errors.Is(err, ErrMoneyOverflow) {
+		t.Errorf("Mul overflow = %v", err)
+	}
+	if _, err := (Money{Cents: math.MinInt64}).Mul(-1); !errors.Is(err, ErrMoneyOverflow) {
+		t.Errorf("Mul overflow = %v", err)
+	}
+}
+
+func TestMoneyFormatAndParse(t *testing.T) {
+	tests := []struct {
+		m      Money
+		locale string
+		want   string
+	}{
+		{NewMoney(123456, "USD"), "en-US", "$1,234.56"},
+		{NewMoney(-5, "USD"), "en", "-$0.05"},
+		{NewMoney(123456, "EUR"), "de-DE", "1.234,56\u00a0€"},
+		{NewMoney(123456, "EUR"), "fr", "1\u202f234,56\u00a0€"},
+		{NewMoney(1234, "JPY"), "ja", "¥1,234"},
+		{NewMoney(1234, "KWD"), "en", "KWD\u00a01.234"},
+		{NewMoney(100, "CHF"), "en", "CHF\u00a01.00"},
+		{Money{Cents: 100}, "en", "1.00"},
+		{NewMoney(math.MinInt64, "USD"), "en", "-$92,233,720,368,547,758.08"},
+	}
+	for _, tt := range tests {
+		got := tt.m.Format(tt.locale)
+		if got != tt.want {
+			t.Errorf("%v.Format(%q) = %q, want %q", tt.m.Cents, tt.locale, got, tt.want)
+			continue
+		}
+		back, err := ParseMoney(got, tt.m.Currency, tt.locale)
+		if err != nil || back.Cents != tt.m.Cents {
+			t.Errorf("ParseMoney(%q) = %v, %v", got, back, err)
+		}
+	}
+
+	for _, in := range []string{"", "$", "1.234", "12.3.4", "1-2", "$1.00 5"} {
+		if m, err := ParseMoney(in, "USD", "en"); err == nil {
+			t.Errorf("ParseMoney(%q) = %v, want an error", in, m)
+		}
+	}
+	if m, err := ParseMoney("(1.50 USD)", "usd", "en"); err != nil || m != NewMoney(-150, "USD") {
+		t.Errorf("ParseMoney of an accounting amount = %v, %v", m, err)
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	var v struct {
+		A Money `json:"a"`
+		B Money `json:"b"`
+		C Money `json:"c"`
+	}
+	if err := json.Unmarshal([]byte(`{"a":1999,"b":"250","c":null}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.A.Cents != 1999 || v.B.Cents != 250 || v.C.Cents != 0 {
+		t.Fatalf("got %+v", v)
+	}
+	if b, _ := json.Marshal(v.A.In("EUR")); string(b) != "1999" {
+		t.Errorf("Marshal = %s", b)
+	}
+	if err := json.Unmarshal([]byte(`{"a":19.99}`), &v); err == nil {
+		t.Error("Unmarshal accepted a fractional amount")
+	}
+}
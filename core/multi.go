@@ -0,0 +1,116 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// MultiClient hands out per-store views of one configured client. All views
+// share the HTTP client, cache, circuit breaker and middleware; each keeps
+// its own credentials and rate-limit budget.
+type MultiClient struct {
+	base *Client
+
+	mu    sync.Mutex
+	keys  map[string]string
+	views map[string]*Client
+}
+
+// NewMulti configures the shared client. apiKey (or the key resolved by a
+// WithCredentialsProvider option) is used for stores that were not registered
+// with their own key through AddStore.
+func NewMulti(apiKey string, opts ...Option) *MultiClient {
+	return &MultiClient{
+		base:  New(apiKey, "", opts...),
+		keys:  map[string]string{},
+		views: map[string]*Client{},
+	}
+}
+
+// AddStore registers the API key for storeID, replacing any previous one.
+func (m *MultiClient) AddStore(storeID, apiKey string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.keys[storeID] = apiKey
+	delete(m.views, storeID)
+}
+
+// Stores returns the registered store IDs in sorted order.
+func (m *MultiClient) Stores() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ids := make([]string, 0, len(m.keys))
+	for id := range m.keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Store returns the view for storeID, creating it on first use.
+func (m *MultiClient) Store(storeID string) *Client {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if v, ok := m.views[storeID]; ok {
+		return v
+	}
+	opts := []Option{WithStoreID(storeID)}
+	if key, ok := m.keys[storeID]; ok {
+		opts = append(opts, WithAPIKey(key))
+	}
+	v := m.base.With(opts...)
+	m.views[storeID] = v
+	return v
+}
+
+// StoreError wraps an error returned for one store by ForEach.
+type StoreError struct {
+	StoreID string
+	Err     error
+}
+
+func (e *StoreError) Error() string { return fmt.Sprintf("store %s: %v", e.StoreID, e.Err) }
+func (e *StoreError) Unwrap() error { return e.Err }
+
+// ForEach calls fn for every store in storeIDs (all registered stores if
+// empty), running at most concurrency calls at once. It waits for every call
+// and returns their errors joined, each wrapped in a *StoreError.
+func (m *MultiClient) ForEach(ctx context.Context, concurrency int, storeIDs []string, fn func(ctx context.Context, storeID string, c *Client) error) error {
+	if len(storeIDs) == 0 {
+		storeIDs = m.Stores()
+	}
+	if concurrency <= 0 {
+		concurrency = len(storeIDs)
+	}
+
+	sem := make(chan struct{}, concurrency)
+	errs := make([]error, len(storeIDs))
+	var wg sync.WaitGroup
+	for i, id := range storeIDs {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			errs[i] = &StoreError{StoreID: id, Err: ctx.Err()}
+			continue
+		}
+		wg.Add(1)
+		go func(i int, id string) {
+			defer func() { <-sem; wg.Done() }()
+			if err := fn(ctx, id, m.Store(id)); err != nil {
+				errs[i] = &StoreError{StoreID: id, Err: err}
+			}
+		}(i, id)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func (l *RateLimiter) fork() *RateLimiter {
+	if l == nil {
+		return nil
+	}
+	return NewRateLimiter(l.mode)
+}
@@ -0,0 +1,63 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// Tracer starts a span around every call to Do. Route is the normalized path,
+// e.g. /orders/{id}.
+type Tracer interface {
+	Start(ctx context.Context, method, route string) (context.Context, Span)
+}
+
+type Span interface {
+	// End is called once with the response metadata (nil on transport
+	// failures) and the error returned by Do.
+	End(meta *ResponseMeta, err error)
+}
+
+// Metrics receives one observation per call to Do. Status is 0 when no
+// response was received.
+type Metrics interface {
+	ObserveRequest(method, route string, status int, d time.Duration, err error)
+}
+
+func WithTracer(t Tracer) Option   { return func(c *Client) { c.tracer = t } }
+func WithMetrics(m Metrics) Option { return func(c *Client) { c.metrics = m } }
+
+// NormalizeRoute replaces resource IDs in an API path with {id} so it can be
+// used as a low-cardinality label: /tickets/abc/reply becomes /tickets/{id}/reply.
+func NormalizeRoute(path string) string {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	segs := strings.Split(strings.Trim(path, "/"), "/")
+	for i := 1; i < len(segs); i += 2 {
+		segs[i] = "{id}"
+	}
+	return "/" + strings.Join(segs, "/")
+}
+
+func (c *Client) tracing(next Handler) Handler {
+	return func(ctx context.Context, r *Request) (*ResponseMeta, error) {
+		ctx, span := c.tracer.Start(ctx, r.Method, r.Route)
+		meta, err := next(ctx, r)
+		span.End(meta, err)
+		return meta, err
+	}
+}
+
+func (c *Client) measuring(next Handler) Handler {
+	return func(ctx context.Context, r *Request) (*ResponseMeta, error) {
+		start := time.Now()
+		meta, err := next(ctx, r)
+		status := 0
+		if meta != nil {
+			status = meta.Status
+		}
+		c.metrics.ObserveRequest(r.Method, r.Route, status, time.Since(start), err)
+		return meta, err
+	}
+}
@@ -0,0 +1,323 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrPaginationDrift reports that a list changed while it was being paged
+// through, so the pages read may have skipped rows. Use errors.Is; the error
+// itself is a *PaginationDriftError.
+var ErrPaginationDrift = errors.New("sellium: list changed during pagination")
+
+type PaginationDriftError struct {
+	Page   int // page on which the change was noticed
+	Before int // Pagination.Total when the walk started
+	After  int // Pagination.Total reported by Page
+}
+
+func (e *PaginationDriftError) Error() string {
+	return fmt.Sprintf("%v: total went from %d to %d at page %d", ErrPaginationDrift, e.Before, e.After, e.Page)
+}
+
+func (e *PaginationDriftError) Is(target error) bool { return target == ErrPaginationDrift }
+
+// DriftPolicy decides what a Pager does when Pagination.Total changes during
+// a walk. Rows seen before are dropped in every case.
+type DriftPolicy int
+
+const (
+	// DriftFail stops the pager with a *PaginationDriftError, so a walk that
+	// ends without an error saw every row.
+	DriftFail DriftPolicy = iota
+	// DriftIgnore carries on with the new total. Rows moved onto pages
+	// already read are missed.
+	DriftIgnore
+	// DriftRestart starts over from the first page, delivering only rows not
+	// seen yet. Every restart fetches the pages read so far again, so it
+	// suits small lists; after maxDriftRestarts restarts the pager fails as
+	// DriftFail.
+	DriftRestart
+)
+
+const maxDriftRestarts = 3
+
+// PageFunc fetches one page of a list endpoint; page is 1-based.
+type PageFunc[T any] func(ctx context.Context, page int) ([]T, Pagination, error)
+
+// Pager walks the pages of a list endpoint, one page per call to Next:
+//
+//	pager := client.Orders.All(ctx, nil)
+//	for pager.Next() {
+//		for _, order := range pager.Page() { ... }
+//	}
+//	if err := pager.Err(); err != nil { ... }
+//
+// Every page is fetched through Do, so retries, the rate limiter and the
+// circuit breaker apply. Pages are fetched one at a time unless Prefetch is
+// used. A Pager is not safe for concurrent use.
+//
+// Offset pagination shifts when rows are added or removed mid-walk. A Pager
+// created with a key function never delivers the same row twice, and it
+// watches Pagination.Total to notice such changes; see DriftPolicy.
+type Pager[T any] struct {
+	ctx         context.Context
+	fetch       PageFunc[T]
+	key         func(T) string
+	policy      DriftPolicy
+	concurrency int
+	prefetch    *prefetcher[T]
+
+	start      int
+	next       int // page fetched by the following call to Next
+	items      []T
+	pagination Pagination
+	err        error
+	done       bool
+
+	seen     map[string]struct{}
+	total    int // Pagination.Total of the first page of this pass
+	counted  bool
+	restarts int
+}
+
+// NewPager returns a pager starting at page start (1 if start <= 0). key
+// identifies a row for duplicate and drift detection; nil disables both.
+func NewPager[T any](ctx context.Context, start int, key func(T) string, fetch PageFunc[T]) *Pager[T] {
+	if start <= 0 {
+		start = 1
+	}
+	p := &Pager[T]{ctx: ctx, fetch: fetch, key: key, start: start, next: start}
+	if key != nil {
+		p.seen = map[string]struct{}{}
+	}
+	return p
+}
+
+// Next fetches the following page and reports whether there was one. It
+// returns false once the last page was read, the context is done or a request
+// failed; Err tells these apart. Pages made up entirely of rows delivered
+// before are skipped.
+func (p *Pager[T]) Next() bool {
+	for !p.done {
+		items, pagination, ok := p.advance()
+		if !ok {
+			return false
+		}
+		if p.key != nil && p.drifted(pagination) {
+			switch {
+			case p.policy == DriftIgnore:
+				p.total = pagination.Total
+			case p.policy == DriftRestart && p.restarts < maxDriftRestarts:
+				p.restart()
+				continue
+			default:
+				return p.fail(&PaginationDriftError{Page: p.next - 1, Before: p.total, After: pagination.Total})
+			}
+		}
+		p.pagination = pagination
+		if items = p.unseen(items); len(items) > 0 {
+			p.items = items
+			return true
+		}
+	}
+	p.items = nil
+	return false
+}
+
+// OnDrift sets what happens when the list changes mid-walk. The default is
+// DriftFail. It has no effect on pagers without a key function.
+func (p *Pager[T]) OnDrift(policy DriftPolicy) *Pager[T] {
+	p.policy = policy
+	return p
+}
+
+// Prefetch makes the pager fetch up to n pages concurrently once the first
+// page has reported TotalPages. Pages are still delivered in order, and at
+// most n of them are fetched ahead of the one being read. The first failure
+// cancels the fetches still running; Next stops at the first page that did
+// not complete and Err returns that failure. Call Prefetch before the first
+// Next, and Close if you stop reading before the pager is exhausted.
+//
+// Without TotalPages in the response the pager falls back to fetching one
+// page at a time. If the last prefetched page reports more pages, the pager
+// carries on past the original range.
+func (p *Pager[T]) Prefetch(n int) *Pager[T] {
+	p.concurrency = n
+	return p
+}
+
+// Close stops any pages still being prefetched. Next returns false afterwards.
+func (p *Pager[T]) Close() { p.finish() }
+
+// Page returns the items of the current page.
+func (p *Pager[T]) Page() []T { return p.items }
+
+// Pagination returns the pagination data of the current page.
+func (p *Pager[T]) Pagination() Pagination { return p.pagination }
+
+// Err returns the error that stopped the pager, or nil if it ran out of pages.
+func (p *Pager[T]) Err() error { return p.err }
+
+// advance fetches the following page, directly or from the prefetcher.
+func (p *Pager[T]) advance() ([]T, Pagination, bool) {
+	if p.prefetch != nil {
+		return p.nextPrefetched()
+	}
+	if err := p.ctx.Err(); err != nil {
+		return nil, Pagination{}, p.fail(err)
+	}
+	items, pagination, err := p.fetch(p.ctx, p.next)
+	if err != nil {
+		return nil, Pagination{}, p.fail(err)
+	}
+	if len(items) == 0 {
+		return nil, Pagination{}, p.finish()
+	}
+	p.done = !hasMore(pagination, p.next)
+	p.next++
+	if !p.done && p.concurrency > 1 && pagination.TotalPages >= p.next {
+		p.startPrefetch(p.next, pagination.TotalPages)
+	}
+	return items, pagination, true
+}
+
+func (p *Pager[T]) drifted(pagination Pagination) bool {
+	if !p.counted {
+		p.total, p.counted = pagination.Total, true
+		return false
+	}
+	return pagination.Total != p.total
+}
+
+// restart begins a new pass from the first page, keeping the rows seen.
+func (p *Pager[T]) restart() {
+	p.stopPrefetch()
+	p.next, p.done = p.start, false
+	p.counted = false
+	p.restarts++
+}
+
+// unseen drops the rows delivered before and records the others.
+func (p *Pager[T]) unseen(items []T) []T {
+	if p.key == nil {
+		return items
+	}
+	fresh := items[:0:0]
+	for _, item := range items {
+		k := p.key(item)
+		if _, ok := p.seen[k]; ok {
+			continue
+		}
+		p.seen[k] = struct{}{}
+		fresh = append(fresh, item)
+	}
+	return fresh
+}
+
+func (p *Pager[T]) finish() bool {
+	p.stopPrefetch()
+	p.done, p.items = true, nil
+	return false
+}
+
+func (p *Pager[T]) fail(err error) bool {
+	p.err = err
+	return p.finish()
+}
+
+type pageResult[T any] struct {
+	items      []T
+	pagination Pagination
+	err        error
+}
+
+// prefetcher fetches a range of pages concurrently. results receives one
+// future per page in page order; a slot in sem is held from the start of a
+// fetch until the page has been handed to the reader.
+type prefetcher[T any] struct {
+	cancel  context.CancelFunc
+	results chan chan pageResult[T]
+	sem     chan struct{}
+
+	once sync.Once
+	err  error // first failure, which canceled the remaining fetches
+}
+
+func (p *Pager[T]) startPrefetch(from, to int) {
+	ctx, cancel := context.WithCancel(p.ctx)
+	pf := &prefetcher[T]{
+		cancel:  cancel,
+		results: make(chan chan pageResult[T], p.concurrency),
+		sem:     make(chan struct{}, p.concurrency),
+	}
+	p.prefetch = pf
+
+	go func() {
+		defer close(pf.results)
+		for page := from; page <= to; page++ {
+			select {
+			case pf.sem <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			res := make(chan pageResult[T], 1)
+			pf.results <- res // cannot block, sem bounds the futures outstanding
+			go func(page int) {
+				items, pagination, err := p.fetch(ctx, page)
+				if err != nil {
+					pf.once.Do(func() {
+						pf.err = err
+						cancel()
+					})
+				}
+				res <- pageResult[T]{items: items, pagination: pagination, err: err}
+			}(page)
+		}
+	}()
+}
+
+func (p *Pager[T]) stopPrefetch() {
+	if p.prefetch != nil {
+		p.prefetch.cancel()
+		p.prefetch = nil
+	}
+}
+
+func (p *Pager[T]) nextPrefetched() ([]T, Pagination, bool) {
+	pf := p.prefetch
+	res, ok := <-pf.results
+	if !ok {
+		if err := p.ctx.Err(); err != nil {
+			return nil, Pagination{}, p.fail(err)
+		}
+		// The range came from the first page's TotalPages; the list may
+		// have grown since, so ask the last page read.
+		if !hasMore(p.pagination, p.next-1) {
+			return nil, Pagination{}, p.finish()
+		}
+		p.stopPrefetch()
+		return p.advance()
+	}
+	r := <-res
+	<-pf.sem
+	if r.err != nil {
+		return nil, Pagination{}, p.fail(pf.err)
+	}
+	if len(r.items) == 0 {
+		return nil, Pagination{}, p.finish()
+	}
+	p.next++
+	return r.items, r.pagination, true
+}
+
+// hasMore reports whether pages follow page. TotalPages wins over HasMore,
+// which the API omits when false.
+func hasMore(pg Pagination, page int) bool {
+	if pg.TotalPages > 0 {
+		return page < pg.TotalPages
+	}
+	return pg.HasMore
+}
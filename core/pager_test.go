@@ -0,0 +1,290 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// pagedList serves rows in pages of limit, like a list endpoint.
+type pagedList struct {
+	mu    sync.Mutex
+	rows  []string
+	limit int
+
+	// onFetch runs before a page is served, e.g. to change rows.
+	onFetch func(page int)
+}
+
+func newPagedList(n, limit int) *pagedList {
+	l := &pagedList{limit: limit}
+	for i := range n {
+		l.rows = append(l.rows, fmt.Sprintf("r%03d", i))
+	}
+	return l
+}
+
+func (l *pagedList) fetch(ctx context.Context, page int) ([]string, Pagination, error) {
+	if l.onFetch != nil {
+		l.onFetch(page)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, Pagination{}, err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	total := len(l.rows)
+	from, to := min((page-1)*l.limit, total), min(page*l.limit, total)
+	pg := Pagination{Page: page, Limit: l.limit, Total: total, TotalPages: (total + l.limit - 1) / l.limit}
+	return slices.Clone(l.rows[from:to]), pg, nil
+}
+
+func collect[T any](p *Pager[T]) []T {
+	var out []T
+	for p.Next() {
+		out = append(out, p.Page()...)
+	}
+	return out
+}
+
+func TestPagerSequential(t *testing.T) {
+	l := newPagedList(25, 10)
+	var pages []int
+	l.onFetch = func(page int) { pages = append(pages, page) }
+
+	got := collect(NewPager(context.Background(), 0, nil, l.fetch))
+	if err := slices.Compare(got, l.rows); err != 0 {
+		t.Fatalf("got %v", got)
+	}
+	if !slices.Equal(pages, []int{1, 2, 3}) {
+		t.Fatalf("fetched pages %v", pages)
+	}
+}
+
+func TestPagerPrefetchOrderAndConcurrency(t *testing.T) {
+	l := newPagedList(200, 10)
+	var inFlight, peak atomic.Int32
+	l.onFetch = func(page int) {
+		n := inFlight.Add(1)
+		for {
+			old := peak.Load()
+			if n <= old || peak.CompareAndSwap(old, n) {
+				break
+			}
+		}
+		time.Sleep(time.Duration(20-page%20) * time.Millisecond) // later pages finish first
+		inFlight.Add(-1)
+	}
+
+	p := NewPager(context.Background(), 1, nil, l.fetch).Prefetch(4)
+	got := collect(p)
+	if err := p.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got, l.rows) {
+		t.Fatalf("rows out of order or missing: got %d rows", len(got))
+	}
+	if n := peak.Load(); n > 4 {
+		t.Fatalf("%d pages fetched at once, want at most 4", n)
+	}
+}
+
+func TestPagerPrefetchStopsAtFirstError(t *testing.T) {
+	boom := errors.New("boom")
+	l := newPagedList(100, 10)
+	fetch := func(ctx context.Context, page int) ([]string, Pagination, error) {
+		if page == 4 {
+			return nil, Pagination{}, boom
+		}
+		return l.fetch(ctx, page)
+	}
+
+	p := NewPager(context.Background(), 1, nil, fetch).Prefetch(3)
+	got := collect(p)
+	if !errors.Is(p.Err(), boom) {
+		t.Fatalf("Err = %v, want boom", p.Err())
+	}
+	// pages fetched alongside the failing one may be canceled by it
+	if len(got) < 10 || len(got) > 30 || !slices.Equal(got, l.rows[:len(got)]) {
+		t.Fatalf("got %d rows, want a prefix of the 30 before the failing page", len(got))
+	}
+}
+
+func TestPagerPrefetchClose(t *testing.T) {
+	l := newPagedList(1000, 10)
+	var fetched atomic.Int32
+	canceled := make(chan struct{}, 100)
+	fetch := func(ctx context.Context, page int) ([]string, Pagination, error) {
+		fetched.Add(1)
+		if page > 1 {
+			<-ctx.Done()
+			canceled <- struct{}{}
+			return nil, Pagination{}, ctx.Err()
+		}
+		return l.fetch(ctx, page)
+	}
+
+	p := NewPager(context.Background(), 1, nil, fetch).Prefetch(3)
+	if !p.Next() {
+		t.Fatal(p.Err())
+	}
+	time.Sleep(10 * time.Millisecond)
+	p.Close()
+	for range fetched.Load() - 1 {
+		select {
+		case <-canceled:
+		case <-time.After(time.Second):
+			t.Fatal("prefetched fetch not canceled by Close")
+		}
+	}
+	if p.Next() {
+		t.Fatal("Next after Close returned true")
+	}
+	if n := fetched.Load(); n > 4 {
+		t.Fatalf("%d pages fetched, want at most 1 + 3 prefetched", n)
+	}
+}
+
+func TestPagerPrefetchContinuesPastGrowth(t *testing.T) {
+	l := newPagedList(30, 10)
+	var grown sync.Once
+	l.onFetch = func(page int) {
+		if page == 3 { // last page of the range announced by page 1
+			grown.Do(func() {
+				l.mu.Lock()
+				for i := 30; i < 50; i++ {
+					l.rows = append(l.rows, fmt.Sprintf("r%03d", i))
+				}
+				l.mu.Unlock()
+			})
+		}
+	}
+
+	p := NewPager(context.Background(), 1, nil, l.fetch).Prefetch(2)
+	got := collect(p)
+	if err := p.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 50 || !slices.Equal(got, l.rows) {
+		t.Fatalf("got %d rows, want all 50 after the list grew", len(got))
+	}
+}
+
+func TestPagerContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	l := newPagedList(50, 10)
+	p := NewPager(ctx, 1, nil, l.fetch)
+	if !p.Next() {
+		t.Fatal(p.Err())
+	}
+	cancel()
+	if p.Next() {
+		t.Fatal("Next after cancel returned true")
+	}
+	if !errors.Is(p.Err(), context.Canceled) {
+		t.Fatalf("Err = %v", p.Err())
+	}
+}
+
+func identity(s string) string { return s }
+
+// insertAt returns an onFetch hook that inserts a row at the head of the
+// list just before page is served, once.
+func (l *pagedList) insertAt(page int) func(int) {
+	var once sync.Once
+	return func(p int) {
+		if p != page {
+			return
+		}
+		once.Do(func() {
+			l.mu.Lock()
+			l.rows = append([]string{"new"}, l.rows...)
+			l.mu.Unlock()
+		})
+	}
+}
+
+func TestPagerDriftIgnore(t *testing.T) {
+	l := newPagedList(30, 10)
+	var pages []int
+	insert := l.insertAt(2)
+	l.onFetch = func(page int) { pages = append(pages, page); insert(page) }
+
+	p := NewPager(context.Background(), 1, identity, l.fetch).OnDrift(DriftIgnore)
+	got := collect(p)
+	if err := p.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(pages, []int{1, 2, 3, 4}) {
+		t.Fatalf("fetched pages %v, want a single pass", pages)
+	}
+	// r009 is repeated on page 2 after the insert and must not come back
+	want := newPagedList(30, 10).rows
+	if !slices.Equal(got, want) {
+		t.Fatalf("got %v", got)
+	}
+}
+
+func TestPagerDriftFailsByDefault(t *testing.T) {
+	l := newPagedList(30, 10)
+	l.onFetch = l.insertAt(2)
+
+	p := NewPager(context.Background(), 1, identity, l.fetch) // DriftFail is the default
+	got := collect(p)
+	var driftErr *PaginationDriftError
+	if !errors.Is(p.Err(), ErrPaginationDrift) || !errors.As(p.Err(), &driftErr) {
+		t.Fatalf("Err = %v, want a *PaginationDriftError", p.Err())
+	}
+	if driftErr.Page != 2 || driftErr.Before != 30 || driftErr.After != 31 {
+		t.Fatalf("got %+v", driftErr)
+	}
+	if len(got) != 10 {
+		t.Fatalf("got %d rows, want only the first page", len(got))
+	}
+}
+
+func TestPagerDriftRestart(t *testing.T) {
+	l := newPagedList(30, 10)
+	var pages []int
+	insert := l.insertAt(2)
+	l.onFetch = func(page int) { pages = append(pages, page); insert(page) }
+
+	p := NewPager(context.Background(), 1, identity, l.fetch).OnDrift(DriftRestart)
+	got := collect(p)
+	if err := p.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(pages, []int{1, 2, 1, 2, 3, 4}) {
+		t.Fatalf("fetched pages %v", pages)
+	}
+	slices.Sort(got)
+	want := append(newPagedList(30, 10).rows, "new")
+	slices.Sort(want)
+	if !slices.Equal(got, want) {
+		t.Fatalf("got %v, want every row once", got)
+	}
+}
+
+func TestPagerDriftRestartGivesUp(t *testing.T) {
+	l := newPagedList(30, 10)
+	var n int
+	l.onFetch = func(page int) {
+		if page == 2 {
+			l.mu.Lock()
+			n++
+			l.rows = append(l.rows, fmt.Sprintf("x%d", n))
+			l.mu.Unlock()
+		}
+	}
+
+	p := NewPager(context.Background(), 1, identity, l.fetch).OnDrift(DriftRestart)
+	collect(p)
+	if !errors.Is(p.Err(), ErrPaginationDrift) {
+		t.Fatalf("Err = %v, want ErrPaginationDrift after %d restarts", p.Err(), maxDriftRestarts)
+	}
+}
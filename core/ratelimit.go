@@ -0,0 +1,116 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type RateLimitMode int
+
+const (
+	// RateLimitBlock waits for the bucket to refill, honoring the request context.
+	RateLimitBlock RateLimitMode = iota
+	// RateLimitFailFast returns a *RateLimitError instead of waiting.
+	RateLimitFailFast
+)
+
+// RateLimitError is returned in fail-fast mode when the local bucket is empty.
+type RateLimitError struct {
+	Limit      int
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("sellium: client rate limit of %d reached, retry in %s", e.Limit, e.RetryAfter)
+}
+
+// RateLimiter is a token bucket sized and refilled from the X-RateLimit-*
+// headers of previous responses. Until the first response arrives it lets
+// every request through.
+type RateLimiter struct {
+	mode RateLimitMode
+
+	mu      sync.Mutex
+	known   bool
+	limit   int
+	tokens  int
+	resetAt time.Time
+}
+
+func NewRateLimiter(mode RateLimitMode) *RateLimiter { return &RateLimiter{mode: mode} }
+
+func WithRateLimiter(mode RateLimitMode) Option {
+	return func(c *Client) { c.limiter = NewRateLimiter(mode) }
+}
+
+// Wait takes one token, blocking or failing according to the limiter mode.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		if l.known && !now.Before(l.resetAt) {
+			l.tokens = l.limit
+			l.known = false
+		}
+		if !l.known || l.tokens > 0 {
+			l.tokens--
+			l.mu.Unlock()
+			return nil
+		}
+		wait, limit := l.resetAt.Sub(now), l.limit
+		l.mu.Unlock()
+
+		if l.mode == RateLimitFailFast {
+			return &RateLimitError{Limit: limit, RetryAfter: wait}
+		}
+		if err := sleep(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// Update folds the rate limit state of a response into the bucket.
+func (l *RateLimiter) Update(status int, h http.Header) {
+	if l == nil {
+		return
+	}
+	rl := parseRateLimit(h)
+	if status == http.StatusTooManyRequests {
+		d, ok := retryAfter(h)
+		if !ok {
+			return
+		}
+		l.mu.Lock()
+		l.known = true
+		l.tokens = 0
+		if rl != nil && rl.Limit > 0 {
+			l.limit = rl.Limit
+		}
+		l.resetAt = time.Now().Add(d)
+		l.mu.Unlock()
+		return
+	}
+	if rl == nil || rl.Limit <= 0 {
+		return
+	}
+
+	resetAt := time.Now().Add(resetDelay(int64(rl.ResetSec)))
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	// Within the same window other in-flight requests may already have
+	// taken tokens the server has not seen yet, so never raise the count.
+	if d := resetAt.Sub(l.resetAt); l.known && d > -time.Second && d < time.Second {
+		l.tokens = min(l.tokens, rl.Remaining)
+	} else {
+		l.tokens = rl.Remaining
+	}
+	l.known = true
+	l.limit = rl.Limit
+	l.resetAt = resetAt
+}
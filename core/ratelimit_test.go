@@ -0,0 +1,102 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func rateLimitHeader(limit, remaining, resetSec int) http.Header {
+	h := http.Header{}
+	h.Set("X-RateLimit-Limit", strconv.Itoa(limit))
+	h.Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	h.Set("X-RateLimit-Reset", strconv.Itoa(resetSec))
+	return h
+}
+
+func TestRateLimiterFailFast(t *testing.T) {
+	l := NewRateLimiter(RateLimitFailFast)
+	l.Update(http.StatusOK, rateLimitHeader(10, 2, 60))
+	for range 2 {
+		if err := l.Wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := l.Wait(context.Background())
+	var rlErr *RateLimitError
+	if !errors.As(err, &rlErr) || rlErr.Limit != 10 || rlErr.RetryAfter <= 0 {
+		t.Fatalf("got %v, want a *RateLimitError", err)
+	}
+}
+
+func TestRateLimiterBlocksUntilReset(t *testing.T) {
+	l := NewRateLimiter(RateLimitBlock)
+	h := http.Header{}
+	h.Set("Retry-After", "1")
+	l.Update(http.StatusTooManyRequests, h)
+
+	start := time.Now()
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Since(start); d < 900*time.Millisecond {
+		t.Errorf("waited %v, want about a second", d)
+	}
+
+	l.Update(http.StatusTooManyRequests, h)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("canceled wait = %v", err)
+	}
+}
+
+func TestRateLimiterNeverRaisesTokensInWindow(t *testing.T) {
+	l := NewRateLimiter(RateLimitFailFast)
+	l.Update(http.StatusOK, rateLimitHeader(10, 1, 60))
+	// a response that left the server before the one above
+	l.Update(http.StatusOK, rateLimitHeader(10, 5, 60))
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Wait(context.Background()); err == nil {
+		t.Fatal("stale header raised the token count")
+	}
+}
+
+func TestRateLimiterConcurrent(t *testing.T) {
+	l := NewRateLimiter(RateLimitFailFast)
+	l.Update(http.StatusOK, rateLimitHeader(100, 50, 60))
+
+	var granted atomic.Int32
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 10 {
+				if l.Wait(context.Background()) == nil {
+					granted.Add(1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if n := granted.Load(); n != 50 {
+		t.Fatalf("granted %d tokens, want 50", n)
+	}
+}
+
+func TestRateLimiterUnknownLetsThrough(t *testing.T) {
+	l := NewRateLimiter(RateLimitFailFast)
+	for range 100 {
+		if err := l.Wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
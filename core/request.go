@@ -1,13 +1,13 @@
 package core
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"errors"
+	"crypto/sha256"
+	"encoding/hex"
 	"io"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
 )
 
@@ -18,9 +18,14 @@ type RateLimit struct {
 }
 
 type ResponseMeta struct {
-	Headers   http.Header
-	Status    int
-	RateLimit *RateLimit
+	Headers       http.Header
+	Status        int
+	RateLimit     *RateLimit
+	RequestID     string // server-assigned request ID (X-Request-ID and similar)
+	CorrelationID string // the ID attached with ContextWithCorrelationID; empty if Coalesced
+	Attempts      int
+	Coalesced     bool // the response was shared with an identical in-flight request
+	Cached        bool // the body was served from the response cache
 }
 
 type envelope[T any] struct {
@@ -29,89 +34,280 @@ type envelope[T any] struct {
 	Error   *APIErrorBody `json:"error,omitempty"`
 }
 
-func (c *Client) Do(ctx context.Context, method, path string, query url.Values, body any, out any) (*ResponseMeta, error) {
-	u := c.BaseURL + path
-	if query != nil && len(query) > 0 {
-		u += "?" + query.Encode()
+func (c *Client) Do(ctx context.Context, method, path string, query url.Values, body any, out any, opts ...CallOption) (*ResponseMeta, error) {
+	return c.handler()(ctx, &Request{
+		Method: method,
+		Path:   path,
+		Route:  NormalizeRoute(path),
+		Query:  query,
+		Body:   body,
+		Out:    out,
+		call:   newCallOptions(opts),
+	})
+}
+
+func (c *Client) do(ctx context.Context, r *Request) (*ResponseMeta, error) {
+	ctx, cancel := r.call.apply(ctx)
+	defer cancel()
+
+	creds, err := c.credentials(ctx)
+	if err != nil {
+		return nil, err
 	}
+	if r.call.storeID != "" {
+		creds.StoreID = r.call.storeID
+	}
+	body, err := c.newPayload(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	meta, err := c.exchange(ctx, r, creds, body)
+	if meta != nil && meta.Status == http.StatusUnauthorized && body.replayable() {
+		if fresh, ok := c.refreshCredentials(ctx, creds); ok {
+			if r.call.storeID != "" {
+				fresh.StoreID = r.call.storeID
+			}
+			return c.exchange(ctx, r, fresh, body)
+		}
+	}
+	return meta, err
+}
 
-	var rdr io.Reader
-	if body != nil {
-		b, err := json.Marshal(body)
+func (c *Client) exchange(ctx context.Context, r *Request, creds Credentials, payload *payload) (*ResponseMeta, error) {
+	baseURL := c.baseURL
+	if r.call.baseURL != "" {
+		baseURL = r.call.baseURL
+	}
+	u := baseURL + r.Path
+	if len(r.Query) > 0 {
+		u += "?" + r.Query.Encode()
+	}
+
+	header := r.call.header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set("X-API-Key", creds.APIKey)
+	header.Set("X-Store-ID", creds.StoreID)
+	key, hasKey := idempotencyKey(ctx)
+	if hasKey {
+		header.Set("Idempotency-Key", key)
+	}
+	correlationID := CorrelationID(ctx)
+	if correlationID != "" {
+		header.Set("X-Correlation-ID", correlationID)
+	}
+
+	attempts := 1
+	if payload.replayable() {
+		attempts = c.retry.attempts(r.Method, hasKey)
+	}
+	fetch := func(ctx context.Context, header http.Header) (*ResponseMeta, io.ReadCloser, error) {
+		return c.roundTrip(ctx, r.Method, u, header, payload, attempts)
+	}
+
+	if r.Method == http.MethodGet && (c.cache != nil || c.coalescer != nil) {
+		meta, raw, err := c.get(ctx, r.Route, cacheKey(creds, u, r.call.header), header, fetch)
 		if err != nil {
-			return nil, err
+			return meta, err
+		}
+		if !meta.Coalesced {
+			// a shared round trip carried another caller's ID
+			meta.CorrelationID = correlationID
 		}
-		rdr = bytes.NewReader(b)
+		r.call.capture(meta, raw)
+		return meta, decodeBytes(meta, raw, r.Out)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, u, rdr)
+	meta, body, err := fetch(ctx, header)
 	if err != nil {
-		return nil, err
+		return meta, err
+	}
+	meta.CorrelationID = correlationID
+	defer body.Close()
+	if r.Method != http.MethodGet && meta.Status < 300 {
+		c.cache.invalidate(creds.StoreID, baseURL, r.Path)
 	}
+	if r.call.raw != nil {
+		raw, err := io.ReadAll(c.limitBody(body))
+		if err != nil {
+			return meta, err
+		}
+		r.call.capture(meta, raw)
+		return meta, decodeBytes(meta, raw, r.Out)
+	}
+	return meta, decode(meta, c.limitBody(body), r.Out)
+}
 
-	req.Header.Set("X-API-Key", c.APIKey)
-	req.Header.Set("X-Store-ID", c.StoreID)
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("User-Agent", c.UserAgent)
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
+// cacheKey identifies a GET for the cache and the coalescer. It starts with
+// the store ID and URL, so invalidation can drop a collection by prefix, and
+// ends with a digest of the API key and any per-call headers: responses are
+// never shared between keys, which the API may authorize differently, or
+// between calls whose headers may change the response.
+func cacheKey(creds Credentials, u string, header http.Header) string {
+	h := sha256.New()
+	io.WriteString(h, creds.APIKey)
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
 	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, v := range header[name] {
+			io.WriteString(h, "\x00"+name+"\x00"+v)
+		}
+	}
+	return creds.StoreID + " " + u + " " + hex.EncodeToString(h.Sum(nil)[:8])
+}
 
-	res, err := c.HTTP.Do(req)
-	if err != nil {
-		return nil, err
+type fetchFunc func(ctx context.Context, header http.Header) (*ResponseMeta, io.ReadCloser, error)
+
+// get serves a GET request from the cache when possible and coalesces it with
+// identical in-flight requests otherwise. Both need the body buffered.
+func (c *Client) get(ctx context.Context, route, key string, header http.Header, fetch fetchFunc) (*ResponseMeta, []byte, error) {
+	cached, fresh := c.cache.lookup(key, route)
+	if fresh {
+		return &ResponseMeta{
+			Headers:   cached.Header.Clone(),
+			Status:    cached.Status,
+			RequestID: requestID(cached.Header),
+			Cached:    true,
+		}, cached.Body, nil
+	}
+	if cached != nil {
+		header = conditional(header, cached)
 	}
-	defer res.Body.Close()
 
-	meta := &ResponseMeta{
-		Headers:   res.Header.Clone(),
-		Status:    res.StatusCode,
-		RateLimit: parseRateLimit(res.Header),
+	buffered := func(ctx context.Context) (*ResponseMeta, []byte, error) {
+		meta, body, err := fetch(ctx, header)
+		if err != nil {
+			return meta, nil, err
+		}
+		defer body.Close()
+		raw, err := io.ReadAll(c.limitBody(body))
+		return meta, raw, err
 	}
 
-	raw, err := io.ReadAll(res.Body)
-	if err != nil {
-		return meta, err
+	var meta *ResponseMeta
+	var raw []byte
+	var err error
+	if c.coalescer != nil {
+		meta, raw, err = c.coalescer.do(ctx, key, buffered)
+	} else {
+		meta, raw, err = buffered(ctx)
 	}
-	if len(raw) == 0 {
-		return meta, nil
+	if err != nil || c.cache == nil {
+		return meta, raw, err
 	}
 
-	var probe envelope[json.RawMessage]
-	if err := json.Unmarshal(raw, &probe); err == nil {
-		if probe.Success {
-			if out == nil {
-				return meta, nil
-			}
-			return meta, json.Unmarshal(raw, out)
+	if meta.Status == http.StatusNotModified && cached != nil {
+		c.cache.refresh(key, route, cached, meta)
+		meta.Status = cached.Status
+		meta.Cached = true
+		return meta, cached.Body, nil
+	}
+	c.cache.store(key, route, meta, raw)
+	return meta, raw, nil
+}
+
+// roundTrip sends the request, retrying according to the client's policy, and
+// returns the still open body of the final attempt.
+func (c *Client) roundTrip(ctx context.Context, method, u string, header http.Header, payload *payload, attempts int) (*ResponseMeta, io.ReadCloser, error) {
+	for attempt := 1; ; attempt++ {
+		gen, err := c.breaker.allow()
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := c.limiter.Wait(ctx); err != nil {
+			c.breaker.record(gen, breakerIgnored)
+			return nil, nil, err
+		}
+
+		meta, body, err := c.send(ctx, method, u, header, payload)
+		c.breaker.record(gen, breakerResult(ctx, meta, err))
+		if meta != nil {
+			meta.Attempts = attempt
+			c.limiter.Update(meta.Status, meta.Headers)
 		}
 
-		if probe.Error != nil {
-			return meta, &APIError{
-				Status:  res.StatusCode,
-				Code:    probe.Error.Code,
-				Message: probe.Error.Message,
-				Raw:     raw,
+		if attempt < attempts {
+			var retry bool
+			if err != nil {
+				retry = retryableNetErr(ctx, err)
+			} else {
+				retry = retryableStatus(meta.Status)
+			}
+			if retry {
+				var h http.Header
+				if meta != nil {
+					h = meta.Headers
+				}
+				wait, ok := c.retry.delay(attempt, h)
+				if !ok {
+					return meta, body, err
+				}
+				if body != nil {
+					discard(body)
+				}
+				if err := sleep(ctx, wait); err != nil {
+					return meta, nil, err
+				}
+				continue
 			}
 		}
+
+		return meta, body, err
+	}
+}
+
+func (c *Client) send(ctx context.Context, method, u string, header http.Header, payload *payload) (*ResponseMeta, io.ReadCloser, error) {
+	rdr, failed := payload.open()
+	req, err := http.NewRequestWithContext(ctx, method, u, rdr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	setDefault(req.Header, "Accept", "application/json")
+	setDefault(req.Header, "Accept-Encoding", "gzip")
+	setDefault(req.Header, "User-Agent", c.userAgent)
+	if payload != nil {
+		setDefault(req.Header, "Content-Type", "application/json")
+		if payload.gzip {
+			req.Header.Set("Content-Encoding", "gzip")
+		}
 	}
 
-	if res.StatusCode < 200 || res.StatusCode >= 300 {
-		return meta, &APIError{
-			Status:  res.StatusCode,
-			Code:    "HTTP_ERROR",
-			Message: "request failed",
-			Raw:     raw,
+	res, err := c.http.Do(req)
+	if err != nil {
+		if ferr := failed(); ferr != nil {
+			return nil, nil, ferr
 		}
+		return nil, nil, err
 	}
+	decompress(res)
 
-	if out == nil {
-		return meta, nil
+	meta := &ResponseMeta{
+		Headers:   res.Header,
+		Status:    res.StatusCode,
+		RateLimit: parseRateLimit(res.Header),
+		RequestID: requestID(res.Header),
 	}
-	if err := json.Unmarshal(raw, out); err != nil {
-		return meta, errors.New("failed to decode response: " + err.Error())
+	return meta, res.Body, nil
+}
+
+func setDefault(h http.Header, key, value string) {
+	if h.Get(key) == "" {
+		h.Set(key, value)
 	}
-	return meta, nil
+}
+
+// discard drains a little of an unused body so the connection can be reused.
+func discard(body io.ReadCloser) {
+	_, _ = io.CopyN(io.Discard, body, 64<<10)
+	body.Close()
 }
 
 func parseRateLimit(h http.Header) *RateLimit {
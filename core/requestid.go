@@ -0,0 +1,33 @@
+package core
+
+import (
+	"context"
+	"net/http"
+)
+
+// Headers the API (or a proxy in front of it) may use to identify a request,
+// in order of preference.
+var requestIDHeaders = []string{"X-Request-ID", "Request-ID", "X-Amzn-RequestId", "CF-Ray"}
+
+type correlationIDCtx struct{}
+
+// ContextWithCorrelationID sends id as the X-Correlation-ID header on every
+// request made with the returned context. It is echoed in ResponseMeta,
+// APIError and the client's logs.
+func ContextWithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDCtx{}, id)
+}
+
+func CorrelationID(ctx context.Context) string {
+	id, _ := ctx.Value(correlationIDCtx{}).(string)
+	return id
+}
+
+func requestID(h http.Header) string {
+	for _, name := range requestIDHeaders {
+		if v := h.Get(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,133 @@
+package core
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how Do repeats requests that failed with a transient
+// error (429, 502, 503, 504 or a transient network error, see IsRetryable).
+type RetryPolicy struct {
+	MaxAttempts int           // total attempts including the first; <= 1 disables retries
+	BaseDelay   time.Duration // backoff for the first retry, doubled on each attempt
+	MaxDelay    time.Duration // upper bound for a single wait; a longer Retry-After ends the retries
+
+	// RetryUnsafe allows retrying methods that are not idempotent (POST, PATCH)
+	// even when the request carries no Idempotency-Key.
+	RetryUnsafe bool
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    30 * time.Second,
+	}
+}
+
+// WithRetry sets the retry policy. Zero BaseDelay and MaxDelay take their
+// values from DefaultRetryPolicy.
+func WithRetry(p RetryPolicy) Option {
+	def := DefaultRetryPolicy()
+	if p.BaseDelay <= 0 {
+		p.BaseDelay = def.BaseDelay
+	}
+	if p.MaxDelay <= 0 {
+		p.MaxDelay = max(def.MaxDelay, p.BaseDelay)
+	}
+	return func(c *Client) { c.retry = &p }
+}
+
+func (p *RetryPolicy) attempts(method string, hasKey bool) int {
+	if p == nil || p.MaxAttempts <= 1 {
+		return 1
+	}
+	if !p.RetryUnsafe && !hasKey && !idempotent(method) {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func retryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+func retryableNetErr(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	return IsRetryable(err)
+}
+
+// delay returns how long to wait before the given retry (1-based). Server
+// hints win over the computed backoff; if the server asks for more than
+// MaxDelay, delay reports false and the request is not retried.
+func (p *RetryPolicy) delay(retry int, h http.Header) (time.Duration, bool) {
+	if d, ok := retryAfter(h); ok {
+		return d, d <= p.MaxDelay
+	}
+
+	backoff := p.BaseDelay << (retry - 1)
+	if backoff <= 0 || backoff > p.MaxDelay {
+		backoff = p.MaxDelay
+	}
+	// full jitter
+	return rand.N(backoff + 1), true
+}
+
+func retryAfter(h http.Header) (time.Duration, bool) {
+	if h == nil {
+		return 0, false
+	}
+	if v := h.Get("Retry-After"); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+			return time.Duration(secs) * time.Second, true
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			return max(time.Until(t), 0), true
+		}
+	}
+	if h.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64); err == nil && reset > 0 {
+			return resetDelay(reset), true
+		}
+	}
+	return 0, false
+}
+
+// resetDelay accepts either seconds until reset or a unix timestamp.
+func resetDelay(reset int64) time.Duration {
+	if reset > 1_000_000_000 {
+		return max(time.Until(time.Unix(reset, 0)), 0)
+	}
+	return time.Duration(reset) * time.Second
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
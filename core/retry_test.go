@@ -0,0 +1,77 @@
+package core
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithRetryFillsDefaults(t *testing.T) {
+	c := New("key", "store", WithRetry(RetryPolicy{MaxAttempts: 4}))
+	def := DefaultRetryPolicy()
+	if c.retry.BaseDelay != def.BaseDelay || c.retry.MaxDelay != def.MaxDelay {
+		t.Fatalf("got BaseDelay=%v MaxDelay=%v, want the defaults", c.retry.BaseDelay, c.retry.MaxDelay)
+	}
+}
+
+func TestRetryHonorsRetryAfter(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"success":true,"data":{}}`))
+	}))
+	defer srv.Close()
+
+	c := New("key", "store", WithBaseURL(srv.URL), WithRetry(RetryPolicy{MaxAttempts: 4}))
+	start := time.Now()
+	meta, err := c.Do(context.Background(), http.MethodGet, "/store", nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.Attempts != 2 {
+		t.Errorf("attempts = %d, want 2", meta.Attempts)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("retried after %v, before the requested Retry-After", elapsed)
+	}
+}
+
+func TestRetryStopsWhenRetryAfterExceedsMaxDelay(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c := New("key", "store", WithBaseURL(srv.URL),
+		WithRetry(RetryPolicy{MaxAttempts: 4, MaxDelay: time.Second}))
+	_, err := c.Do(context.Background(), http.MethodGet, "/store", nil, nil, nil)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if n := hits.Load(); n != 1 {
+		t.Errorf("server hit %d times, want 1", n)
+	}
+	if d, ok := RetryAfter(err); !ok || d != 120*time.Second {
+		t.Errorf("RetryAfter = %v, %v; want 2m0s, true", d, ok)
+	}
+}
+
+func TestDelayBackoffBounds(t *testing.T) {
+	p := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	for retry := 1; retry <= 10; retry++ {
+		d, ok := p.delay(retry, nil)
+		if !ok || d < 0 || d > p.MaxDelay {
+			t.Fatalf("delay(%d) = %v, %v", retry, d, ok)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Timestamp is a point in time sent to or received from the API. It embeds
+// time.Time, so t.Before, t.Format and friends work directly.
+//
+// Unmarshalling accepts RFC 3339 with or without fractional seconds or a zone,
+// "2006-01-02 15:04:05" style values, plain dates and unix seconds or
+// milliseconds; null and "" leave the zero Timestamp. A decoded Timestamp
+// marshals back to exactly the JSON it was read from unless its time was
+// changed. Other values marshal as RFC 3339, and the zero value as null.
+type Timestamp struct {
+	time.Time
+
+	raw    string    // JSON the value was decoded from
+	parsed time.Time // time decoded from raw, to notice later changes
+}
+
+func NewTimestamp(t time.Time) Timestamp { return Timestamp{Time: t} }
+
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999-07",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+// ParseTimestamp parses s in any of the formats the API uses. Values without
+// a zone are taken as UTC.
+func ParseTimestamp(s string) (Timestamp, error) {
+	if s == "" {
+		return Timestamp{}, nil
+	}
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return Timestamp{Time: t}, nil
+		}
+	}
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return Timestamp{Time: unixTime(n)}, nil
+	}
+	return Timestamp{}, fmt.Errorf("sellium: cannot parse %q as a timestamp", s)
+}
+
+// unixTime takes n as milliseconds when it is too large to be seconds.
+func unixTime(n int64) time.Time {
+	if n > 1e12 || n < -1e12 {
+		return time.UnixMilli(n).UTC()
+	}
+	return time.Unix(n, 0).UTC()
+}
+
+func (t *Timestamp) UnmarshalJSON(b []byte) error {
+	var ts Timestamp
+	switch {
+	case bytes.Equal(b, []byte("null")):
+	case len(b) > 0 && b[0] == '"':
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		var err error
+		if ts, err = ParseTimestamp(s); err != nil {
+			return err
+		}
+	default:
+		n, err := strconv.ParseInt(string(b), 10, 64)
+		if err != nil {
+			return fmt.Errorf("sellium: cannot parse %s as a timestamp", b)
+		}
+		ts.Time = unixTime(n)
+	}
+	ts.raw, ts.parsed = string(b), ts.Time
+	*t = ts
+	return nil
+}
+
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.raw != "" && t.Time.Equal(t.parsed) && t.Location() == t.parsed.Location() {
+		return []byte(t.raw), nil
+	}
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(`"` + t.Format(time.RFC3339Nano) + `"`), nil
+}
+
+// String formats the time as RFC 3339, or returns "" for the zero value.
+func (t Timestamp) String() string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339Nano)
+}
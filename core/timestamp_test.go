@@ -0,0 +1,124 @@
+package core
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseTimestamp(t *testing.T) {
+	want := time.Date(2024, 3, 5, 14, 7, 9, 0, time.UTC)
+	tests := map[string]time.Time{
+		"2024-03-05T14:07:09Z":           want,
+		"2024-03-05T16:07:09+02:00":      want,
+		"2024-03-05T14:07:09.250Z":       want.Add(250 * time.Millisecond),
+		"2024-03-05T14:07:09":            want,
+		"2024-03-05 14:07:09":            want,
+		"2024-03-05 14:07:09.5":          want.Add(500 * time.Millisecond),
+		"2024-03-05 16:07:09+02":         want,
+		"2024-03-05 16:07:09+02:00":      want,
+		"2024-03-05":                     time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC),
+		"1709647629":                     want,
+		"1709647629000":                  want,
+		"2024-03-05T14:07:09.123456789Z": want.Add(123456789),
+	}
+	for in, want := range tests {
+		ts, err := ParseTimestamp(in)
+		if err != nil {
+			t.Errorf("ParseTimestamp(%q): %v", in, err)
+			continue
+		}
+		if !ts.Equal(want) {
+			t.Errorf("ParseTimestamp(%q) = %v, want %v", in, ts.Time, want)
+		}
+	}
+	if _, err := ParseTimestamp("yesterday"); err == nil {
+		t.Error("ParseTimestamp accepted garbage")
+	}
+	if ts, err := ParseTimestamp(""); err != nil || !ts.IsZero() {
+		t.Errorf(`ParseTimestamp("") = %v, %v`, ts, err)
+	}
+}
+
+func TestTimestampJSON(t *testing.T) {
+	for _, raw := range []string{`"2024-03-05 14:07:09"`, `"2024-03-05T14:07:09.250+02:00"`, `1709647629`, `null`} {
+		var ts Timestamp
+		if err := json.Unmarshal([]byte(raw), &ts); err != nil {
+			t.Fatalf("Unmarshal(%s): %v", raw, err)
+		}
+		b, err := json.Marshal(ts)
+		if err != nil || string(b) != raw {
+			t.Errorf("round trip of %s = %s, %v", raw, b, err)
+		}
+	}
+
+	var ts Timestamp
+	if err := json.Unmarshal([]byte(`"2024-03-05 14:07:09"`), &ts); err != nil {
+		t.Fatal(err)
+	}
+	ts.Time = ts.Add(time.Hour)
+	if b, _ := json.Marshal(ts); string(b) != `"2024-03-05T15:07:09Z"` {
+		t.Errorf("changed value marshals as %s", b)
+	}
+
+	if b, _ := json.Marshal(Timestamp{}); string(b) != "null" {
+		t.Errorf("zero value marshals as %s", b)
+	}
+	if err := json.Unmarshal([]byte(`true`), &ts); err == nil {
+		t.Error("Unmarshal accepted a bool")
+	}
+}
+
+func TestOptionalTimestampRoundTrip(t *testing.T) {
+	for _, raw := range []string{
+		`{"id":"t1","closed_at":"2024-03-05 14:07:09"}`,
+		`{"id":"t2"}`,
+	} {
+		var v struct {
+			ID       string     `json:"id"`
+			ClosedAt *Timestamp `json:"closed_at,omitempty"`
+		}
+		if err := json.Unmarshal([]byte(raw), &v); err != nil {
+			t.Fatal(err)
+		}
+		b, err := json.Marshal(v)
+		if err != nil || string(b) != raw {
+			t.Errorf("round trip of %s = %s, %v", raw, b, err)
+		}
+	}
+
+	var ticket Ticket
+	if err := json.Unmarshal([]byte(`{"id":"t3","closed_at":null}`), &ticket); err != nil {
+		t.Fatal(err)
+	}
+	if ticket.ClosedAt != nil {
+		t.Errorf("null closed_at decoded as %v", ticket.ClosedAt)
+	}
+
+	var coupon Coupon
+	if err := json.Unmarshal([]byte(`{"id":"c1","created_at":"2024-03-05"}`), &coupon); err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := json.Marshal(coupon); strings.Contains(string(b), "expires_at") {
+		t.Errorf("absent expires_at re-encoded: %s", b)
+	}
+
+	for _, v := range []any{&CustomerRow{}, &CustomerDetail{}} {
+		if err := json.Unmarshal([]byte(`{"email":"a@example.com"}`), v); err != nil {
+			t.Fatal(err)
+		}
+		b, _ := json.Marshal(v)
+		if strings.Contains(string(b), "order_at") {
+			t.Errorf("absent first and last order times re-encoded: %s", b)
+		}
+	}
+	var row CustomerRow
+	raw := `{"email":"a@example.com","first_order_at":"2024-03-05 14:07:09","last_order_at":1709647629}`
+	if err := json.Unmarshal([]byte(raw), &row); err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := json.Marshal(row); !strings.Contains(string(b), `"first_order_at":"2024-03-05 14:07:09","last_order_at":1709647629`) {
+		t.Errorf("order times not re-encoded as sent: %s", b)
+	}
+}
@@ -0,0 +1,54 @@
+package sellium
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Sellium-site/sellium-go/core"
+)
+
+// MultiClient serves many stores from one shared transport, cache and
+// circuit breaker. See core.MultiClient.
+type MultiClient struct {
+	core *core.MultiClient
+
+	mu      sync.Mutex
+	clients map[string]*Client
+}
+
+type StoreError = core.StoreError
+
+func NewMultiClient(apiKey string, opts ...Option) *MultiClient {
+	return &MultiClient{
+		core:    core.NewMulti(apiKey, opts...),
+		clients: map[string]*Client{},
+	}
+}
+
+func (m *MultiClient) Core() *core.MultiClient { return m.core }
+
+// AddStore registers the API key for storeID, replacing any previous one.
+func (m *MultiClient) AddStore(storeID, apiKey string) { m.core.AddStore(storeID, apiKey) }
+
+func (m *MultiClient) Stores() []string { return m.core.Stores() }
+
+// Store returns the client for storeID.
+func (m *MultiClient) Store(storeID string) *Client {
+	cc := m.core.Store(storeID)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if c, ok := m.clients[storeID]; ok && c.core == cc {
+		return c
+	}
+	c := newClient(cc)
+	m.clients[storeID] = c
+	return c
+}
+
+// ForEach calls fn concurrently for the given stores (all registered stores
+// if none are given); see core.MultiClient.ForEach.
+func (m *MultiClient) ForEach(ctx context.Context, concurrency int, fn func(ctx context.Context, storeID string, c *Client) error, storeIDs ...string) error {
+	return m.core.ForEach(ctx, concurrency, storeIDs, func(ctx context.Context, storeID string, _ *core.Client) error {
+		return fn(ctx, storeID, m.Store(storeID))
+	})
+}
@@ -21,6 +21,10 @@ type Client struct {
 
 func (c *Client) Core() *core.Client { return c.core }
 
+// With returns a client with opts applied on top of c's configuration that
+// shares c's transport; see core.Client.With.
+func (c *Client) With(opts ...Option) *Client { return newClient(c.core.With(opts...)) }
+
 // Models
 type (
 	Store            = core.Store
@@ -49,19 +53,178 @@ type (
 	GroupProductMini = core.GroupProductMini
 
 	Pagination = core.Pagination
+	Timestamp  = core.Timestamp
+	Money      = core.Money
+
+	OrderStatus    = core.OrderStatus
+	DeliveryType   = core.DeliveryType
+	PaymentMethod  = core.PaymentMethod
+	BlacklistType  = core.BlacklistType
+	TicketStatus   = core.TicketStatus
+	TicketPriority = core.TicketPriority
+	CouponType     = core.CouponType
+)
+
+const (
+	OrderPending   = core.OrderPending
+	OrderCompleted = core.OrderCompleted
+	OrderCanceled  = core.OrderCanceled
+	OrderRefunded  = core.OrderRefunded
+
+	DeliveryFile    = core.DeliveryFile
+	DeliverySerials = core.DeliverySerials
+	DeliveryService = core.DeliveryService
+	DeliveryDynamic = core.DeliveryDynamic
+
+	PaymentStripe  = core.PaymentStripe
+	PaymentPayPal  = core.PaymentPayPal
+	PaymentCrypto  = core.PaymentCrypto
+	PaymentCashApp = core.PaymentCashApp
+
+	BlacklistEmail   = core.BlacklistEmail
+	BlacklistIP      = core.BlacklistIP
+	BlacklistCountry = core.BlacklistCountry
+
+	TicketOpen    = core.TicketOpen
+	TicketPending = core.TicketPending
+	TicketClosed  = core.TicketClosed
+
+	PriorityLow    = core.PriorityLow
+	PriorityMedium = core.PriorityMedium
+	PriorityHigh   = core.PriorityHigh
+	PriorityUrgent = core.PriorityUrgent
+
+	CouponPercentage = core.CouponPercentage
+	CouponFixed      = core.CouponFixed
 )
 
 type (
 	ResponseMeta = core.ResponseMeta
 	APIError     = core.APIError
+	FieldError   = core.FieldError
+	RetryPolicy  = core.RetryPolicy
+
+	RateLimitMode  = core.RateLimitMode
+	RateLimitError = core.RateLimitError
+
+	Request    = core.Request
+	Handler    = core.Handler
+	Middleware = core.Middleware
+
+	Tracer        = core.Tracer
+	Span          = core.Span
+	Metrics       = core.Metrics
+	ExpvarMetrics = core.ExpvarMetrics
+
+	BreakerConfig  = core.BreakerConfig
+	BreakerState   = core.BreakerState
+	CircuitBreaker = core.CircuitBreaker
+
+	Cache          = core.Cache
+	CacheConfig    = core.CacheConfig
+	CachedResponse = core.CachedResponse
+	LRUCache       = core.LRUCache
+
+	Credentials          = core.Credentials
+	CredentialsProvider  = core.CredentialsProvider
+	CredentialsRefresher = core.CredentialsRefresher
+	StaticCredentials    = core.StaticCredentials
+	EnvCredentials       = core.EnvCredentials
+	FileCredentials      = core.FileCredentials
+
+	CallOption  = core.CallOption
+	RawResponse = core.RawResponse
+
+	EncodeFunc = core.EncodeFunc
+
+	DriftPolicy          = core.DriftPolicy
+	PaginationDriftError = core.PaginationDriftError
+)
+
+const (
+	RateLimitBlock    = core.RateLimitBlock
+	RateLimitFailFast = core.RateLimitFailFast
+
+	BreakerClosed   = core.BreakerClosed
+	BreakerOpen     = core.BreakerOpen
+	BreakerHalfOpen = core.BreakerHalfOpen
+
+	DriftFail    = core.DriftFail
+	DriftIgnore  = core.DriftIgnore
+	DriftRestart = core.DriftRestart
+)
+
+var (
+	ErrCircuitOpen      = core.ErrCircuitOpen
+	ErrResponseTooLarge = core.ErrResponseTooLarge
+	ErrNotFound         = core.ErrNotFound
+	ErrUnauthorized     = core.ErrUnauthorized
+	ErrForbidden        = core.ErrForbidden
+	ErrRateLimited      = core.ErrRateLimited
+	ErrValidation       = core.ErrValidation
+	ErrConflict         = core.ErrConflict
+	ErrServer           = core.ErrServer
+	ErrPaginationDrift  = core.ErrPaginationDrift
+	ErrCurrencyMismatch = core.ErrCurrencyMismatch
+	ErrMoneyOverflow    = core.ErrMoneyOverflow
+
+	IsRetryable = core.IsRetryable
+	RetryAfter  = core.RetryAfter
 )
 
 type Option = core.Option
 
 var (
-	WithBaseURL    = core.WithBaseURL
-	WithHTTPClient = core.WithHTTPClient
-	WithUserAgent  = core.WithUserAgent
+	WithBaseURL     = core.WithBaseURL
+	WithHTTPClient  = core.WithHTTPClient
+	WithUserAgent   = core.WithUserAgent
+	WithAPIKey      = core.WithAPIKey
+	WithStoreID     = core.WithStoreID
+	WithRetry       = core.WithRetry
+	WithRateLimiter = core.WithRateLimiter
+	WithMiddleware  = core.WithMiddleware
+
+	WithLogger          = core.WithLogger
+	WithUnmaskedLogging = core.WithUnmaskedLogging
+	WithTracer          = core.WithTracer
+	WithMetrics         = core.WithMetrics
+
+	WithCircuitBreaker   = core.WithCircuitBreaker
+	DefaultBreakerConfig = core.DefaultBreakerConfig
+
+	WithRequestCoalescing = core.WithRequestCoalescing
+	WithCache             = core.WithCache
+	NewLRUCache           = core.NewLRUCache
+
+	WithMaxResponseBytes = core.WithMaxResponseBytes
+	WithCompression      = core.WithCompression
+
+	WithCredentialsProvider = core.WithCredentialsProvider
+	NewFileCredentials      = core.NewFileCredentials
+
+	// Per-call options
+	WithHeader         = core.WithHeader
+	WithTimeout        = core.WithTimeout
+	WithCallBaseURL    = core.WithCallBaseURL
+	WithCallStoreID    = core.WithCallStoreID
+	WithIdempotencyKey = core.WithIdempotencyKey
+	WithRawResponse    = core.WithRawResponse
+
+	NewTimestamp   = core.NewTimestamp
+	ParseTimestamp = core.ParseTimestamp
+	NewMoney       = core.NewMoney
+	ParseMoney     = core.ParseMoney
+	SumMoney       = core.SumMoney
+
+	NewExpvarMetrics = core.NewExpvarMetrics
+	NormalizeRoute   = core.NormalizeRoute
+
+	DefaultRetryPolicy = core.DefaultRetryPolicy
+
+	ContextWithIdempotencyKey = core.ContextWithIdempotencyKey
+	NewIdempotencyKey         = core.NewIdempotencyKey
+	ContextWithCorrelationID  = core.ContextWithCorrelationID
+	CorrelationID             = core.CorrelationID
 )
 
 type (
@@ -103,8 +266,10 @@ type (
 )
 
 func NewClient(apiKey, storeID string, opts ...Option) *Client {
-	cc := core.New(apiKey, storeID, opts...)
+	return newClient(core.New(apiKey, storeID, opts...))
+}
 
+func newClient(cc *core.Client) *Client {
 	return &Client{
 		core:      cc,
 		Store:     services.NewStore(cc),
@@ -15,7 +15,7 @@ func NewBlacklist(c *core.Client) *BlacklistService { return &BlacklistService{c
 type ListBlacklistParams struct {
 	Page   int
 	Limit  int
-	Type   string
+	Type   core.BlacklistType
 	Search string
 }
 
@@ -27,7 +27,7 @@ type ListBlacklistResponse struct {
 	} `json:"data"`
 }
 
-func (s *BlacklistService) List(ctx context.Context, p *ListBlacklistParams) (*ListBlacklistResponse, *core.ResponseMeta, error) {
+func (s *BlacklistService) List(ctx context.Context, p *ListBlacklistParams, opts ...core.CallOption) (*ListBlacklistResponse, *core.ResponseMeta, error) {
 	q := url.Values{}
 	if p != nil {
 		if p.Page > 0 {
@@ -37,7 +37,7 @@ func (s *BlacklistService) List(ctx context.Context, p *ListBlacklistParams) (*L
 			q.Set("limit", strconv.Itoa(p.Limit))
 		}
 		if p.Type != "" {
-			q.Set("type", p.Type)
+			q.Set("type", string(p.Type))
 		}
 		if p.Search != "" {
 			q.Set("search", p.Search)
@@ -45,25 +45,43 @@ func (s *BlacklistService) List(ctx context.Context, p *ListBlacklistParams) (*L
 	}
 
 	var out ListBlacklistResponse
-	meta, err := s.c.Do(ctx, "GET", "/blacklist", q, nil, &out)
+	meta, err := s.c.Do(ctx, "GET", "/blacklist", q, nil, &out, opts...)
 	return &out, meta, err
 }
 
+// All walks every page of List, starting at p.Page.
+func (s *BlacklistService) All(ctx context.Context, p *ListBlacklistParams, opts ...core.CallOption) *core.Pager[core.BlacklistEntry] {
+	var params ListBlacklistParams
+	if p != nil {
+		params = *p
+	}
+	key := func(v core.BlacklistEntry) string { return v.ID }
+	return core.NewPager(ctx, params.Page, key, func(ctx context.Context, page int) ([]core.BlacklistEntry, core.Pagination, error) {
+		q := params
+		q.Page = page
+		res, _, err := s.List(ctx, &q, opts...)
+		if err != nil {
+			return nil, core.Pagination{}, err
+		}
+		return res.Data.Entries, res.Data.Pagination, nil
+	})
+}
+
 type GetBlacklistEntryResponse struct {
 	Success bool                `json:"success"`
 	Data    core.BlacklistEntry `json:"data"`
 }
 
-func (s *BlacklistService) Get(ctx context.Context, entryID string) (*GetBlacklistEntryResponse, *core.ResponseMeta, error) {
+func (s *BlacklistService) Get(ctx context.Context, entryID string, opts ...core.CallOption) (*GetBlacklistEntryResponse, *core.ResponseMeta, error) {
 	var out GetBlacklistEntryResponse
-	meta, err := s.c.Do(ctx, "GET", "/blacklist/"+entryID, nil, nil, &out)
+	meta, err := s.c.Do(ctx, "GET", "/blacklist/"+entryID, nil, nil, &out, opts...)
 	return &out, meta, err
 }
 
 type CreateBlacklistEntryRequest struct {
-	Type   string `json:"type"` // email|ip|country
-	Value  string `json:"value"`
-	Reason string `json:"reason,omitempty"`
+	Type   core.BlacklistType `json:"type"`
+	Value  string             `json:"value"`
+	Reason string             `json:"reason,omitempty"`
 }
 
 type CreateBlacklistEntryResponse struct {
@@ -71,9 +89,9 @@ type CreateBlacklistEntryResponse struct {
 	Data    core.BlacklistEntry `json:"data"`
 }
 
-func (s *BlacklistService) Create(ctx context.Context, req CreateBlacklistEntryRequest) (*CreateBlacklistEntryResponse, *core.ResponseMeta, error) {
+func (s *BlacklistService) Create(ctx context.Context, req CreateBlacklistEntryRequest, opts ...core.CallOption) (*CreateBlacklistEntryResponse, *core.ResponseMeta, error) {
 	var out CreateBlacklistEntryResponse
-	meta, err := s.c.Do(ctx, "POST", "/blacklist", nil, req, &out)
+	meta, err := s.c.Do(ctx, "POST", "/blacklist", nil, req, &out, opts...)
 	return &out, meta, err
 }
 
@@ -84,8 +102,8 @@ type DeleteBlacklistEntryResponse struct {
 	} `json:"data"`
 }
 
-func (s *BlacklistService) Delete(ctx context.Context, entryID string) (*DeleteBlacklistEntryResponse, *core.ResponseMeta, error) {
+func (s *BlacklistService) Delete(ctx context.Context, entryID string, opts ...core.CallOption) (*DeleteBlacklistEntryResponse, *core.ResponseMeta, error) {
 	var out DeleteBlacklistEntryResponse
-	meta, err := s.c.Do(ctx, "DELETE", "/blacklist/"+entryID, nil, nil, &out)
+	meta, err := s.c.Do(ctx, "DELETE", "/blacklist/"+entryID, nil, nil, &out, opts...)
 	return &out, meta, err
 }
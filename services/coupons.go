@@ -27,7 +27,7 @@ type ListCouponsResponse struct {
 	} `json:"data"`
 }
 
-func (s *CouponsService) List(ctx context.Context, p *ListCouponsParams) (*ListCouponsResponse, *core.ResponseMeta, error) {
+func (s *CouponsService) List(ctx context.Context, p *ListCouponsParams, opts ...core.CallOption) (*ListCouponsResponse, *core.ResponseMeta, error) {
 	q := url.Values{}
 	if p != nil {
 		if p.Page > 0 {
@@ -44,19 +44,37 @@ func (s *CouponsService) List(ctx context.Context, p *ListCouponsParams) (*ListC
 		}
 	}
 	var out ListCouponsResponse
-	meta, err := s.c.Do(ctx, "GET", "/coupons", q, nil, &out)
+	meta, err := s.c.Do(ctx, "GET", "/coupons", q, nil, &out, opts...)
 	return &out, meta, err
 }
 
+// All walks every page of List, starting at p.Page.
+func (s *CouponsService) All(ctx context.Context, p *ListCouponsParams, opts ...core.CallOption) *core.Pager[core.Coupon] {
+	var params ListCouponsParams
+	if p != nil {
+		params = *p
+	}
+	key := func(v core.Coupon) string { return v.ID }
+	return core.NewPager(ctx, params.Page, key, func(ctx context.Context, page int) ([]core.Coupon, core.Pagination, error) {
+		q := params
+		q.Page = page
+		res, _, err := s.List(ctx, &q, opts...)
+		if err != nil {
+			return nil, core.Pagination{}, err
+		}
+		return res.Data.Coupons, res.Data.Pagination, nil
+	})
+}
+
 type CreateCouponRequest struct {
-	Code  string `json:"code"`
-	Type  string `json:"type"`  // percentage|fixed
-	Value int    `json:"value"` // percentage 1-100, fixed cents
-
-	MinimumPurchase *int    `json:"minimum_purchase,omitempty"`
-	MaximumUses     *int    `json:"maximum_uses,omitempty"`
-	IsActive        *bool   `json:"is_active,omitempty"`
-	ExpiresAt       *string `json:"expires_at,omitempty"`
+	Code  string          `json:"code"`
+	Type  core.CouponType `json:"type"`
+	Value int             `json:"value"` // percentage 1-100, fixed cents
+
+	MinimumPurchase *int            `json:"minimum_purchase,omitempty"`
+	MaximumUses     *int            `json:"maximum_uses,omitempty"`
+	IsActive        *bool           `json:"is_active,omitempty"`
+	ExpiresAt       *core.Timestamp `json:"expires_at,omitempty"`
 }
 
 type CouponResponse struct {
@@ -64,9 +82,9 @@ type CouponResponse struct {
 	Data    any  `json:"data"`
 }
 
-func (s *CouponsService) Create(ctx context.Context, req CreateCouponRequest) (*CouponResponse, *core.ResponseMeta, error) {
+func (s *CouponsService) Create(ctx context.Context, req CreateCouponRequest, opts ...core.CallOption) (*CouponResponse, *core.ResponseMeta, error) {
 	var out CouponResponse
-	meta, err := s.c.Do(ctx, "POST", "/coupons", nil, req, &out)
+	meta, err := s.c.Do(ctx, "POST", "/coupons", nil, req, &out, opts...)
 	return &out, meta, err
 }
 
@@ -75,25 +93,25 @@ type GetCouponResponse struct {
 	Data    core.Coupon `json:"data"`
 }
 
-func (s *CouponsService) Get(ctx context.Context, couponID string) (*GetCouponResponse, *core.ResponseMeta, error) {
+func (s *CouponsService) Get(ctx context.Context, couponID string, opts ...core.CallOption) (*GetCouponResponse, *core.ResponseMeta, error) {
 	var out GetCouponResponse
-	meta, err := s.c.Do(ctx, "GET", "/coupons/"+couponID, nil, nil, &out)
+	meta, err := s.c.Do(ctx, "GET", "/coupons/"+couponID, nil, nil, &out, opts...)
 	return &out, meta, err
 }
 
 type UpdateCouponRequest struct {
-	Code            *string `json:"code,omitempty"`
-	Type            *string `json:"type,omitempty"`
-	Value           *int    `json:"value,omitempty"`
-	MinimumPurchase *int    `json:"minimum_purchase,omitempty"`
-	MaximumUses     *int    `json:"maximum_uses,omitempty"`
-	IsActive        *bool   `json:"is_active,omitempty"`
-	ExpiresAt       *string `json:"expires_at,omitempty"`
+	Code            *string          `json:"code,omitempty"`
+	Type            *core.CouponType `json:"type,omitempty"`
+	Value           *int             `json:"value,omitempty"`
+	MinimumPurchase *int             `json:"minimum_purchase,omitempty"`
+	MaximumUses     *int             `json:"maximum_uses,omitempty"`
+	IsActive        *bool            `json:"is_active,omitempty"`
+	ExpiresAt       *core.Timestamp  `json:"expires_at,omitempty"`
 }
 
-func (s *CouponsService) Update(ctx context.Context, couponID string, req UpdateCouponRequest) (*CouponResponse, *core.ResponseMeta, error) {
+func (s *CouponsService) Update(ctx context.Context, couponID string, req UpdateCouponRequest, opts ...core.CallOption) (*CouponResponse, *core.ResponseMeta, error) {
 	var out CouponResponse
-	meta, err := s.c.Do(ctx, "PATCH", "/coupons/"+couponID, nil, req, &out)
+	meta, err := s.c.Do(ctx, "PATCH", "/coupons/"+couponID, nil, req, &out, opts...)
 	return &out, meta, err
 }
 
@@ -105,8 +123,8 @@ type DeleteCouponResponse struct {
 	} `json:"data"`
 }
 
-func (s *CouponsService) Delete(ctx context.Context, couponID string) (*DeleteCouponResponse, *core.ResponseMeta, error) {
+func (s *CouponsService) Delete(ctx context.Context, couponID string, opts ...core.CallOption) (*DeleteCouponResponse, *core.ResponseMeta, error) {
 	var out DeleteCouponResponse
-	meta, err := s.c.Do(ctx, "DELETE", "/coupons/"+couponID, nil, nil, &out)
+	meta, err := s.c.Do(ctx, "DELETE", "/coupons/"+couponID, nil, nil, &out, opts...)
 	return &out, meta, err
 }
@@ -26,7 +26,7 @@ type ListCustomersResponse struct {
 	} `json:"data"`
 }
 
-func (s *CustomersService) List(ctx context.Context, p *ListCustomersParams) (*ListCustomersResponse, *core.ResponseMeta, error) {
+func (s *CustomersService) List(ctx context.Context, p *ListCustomersParams, opts ...core.CallOption) (*ListCustomersResponse, *core.ResponseMeta, error) {
 	q := url.Values{}
 	if p != nil {
 		if p.Page > 0 {
@@ -41,10 +41,28 @@ func (s *CustomersService) List(ctx context.Context, p *ListCustomersParams) (*L
 	}
 
 	var out ListCustomersResponse
-	meta, err := s.c.Do(ctx, "GET", "/customers", q, nil, &out)
+	meta, err := s.c.Do(ctx, "GET", "/customers", q, nil, &out, opts...)
 	return &out, meta, err
 }
 
+// All walks every page of List, starting at p.Page.
+func (s *CustomersService) All(ctx context.Context, p *ListCustomersParams, opts ...core.CallOption) *core.Pager[core.CustomerRow] {
+	var params ListCustomersParams
+	if p != nil {
+		params = *p
+	}
+	key := func(v core.CustomerRow) string { return v.Email }
+	return core.NewPager(ctx, params.Page, key, func(ctx context.Context, page int) ([]core.CustomerRow, core.Pagination, error) {
+		q := params
+		q.Page = page
+		res, _, err := s.List(ctx, &q, opts...)
+		if err != nil {
+			return nil, core.Pagination{}, err
+		}
+		return res.Data.Customers, res.Data.Pagination, nil
+	})
+}
+
 type GetCustomerResponse struct {
 	Success bool `json:"success"`
 	Data    struct {
@@ -53,9 +71,9 @@ type GetCustomerResponse struct {
 	} `json:"data"`
 }
 
-func (s *CustomersService) Get(ctx context.Context, email string) (*GetCustomerResponse, *core.ResponseMeta, error) {
+func (s *CustomersService) Get(ctx context.Context, email string, opts ...core.CallOption) (*GetCustomerResponse, *core.ResponseMeta, error) {
 	encoded := url.PathEscape(email)
 	var out GetCustomerResponse
-	meta, err := s.c.Do(ctx, "GET", "/customers/"+encoded, nil, nil, &out)
+	meta, err := s.c.Do(ctx, "GET", "/customers/"+encoded, nil, nil, &out, opts...)
 	return &out, meta, err
 }
@@ -29,7 +29,7 @@ type ListFeedbackResponse struct {
 	} `json:"data"`
 }
 
-func (s *FeedbackService) List(ctx context.Context, p *ListFeedbackParams) (*ListFeedbackResponse, *core.ResponseMeta, error) {
+func (s *FeedbackService) List(ctx context.Context, p *ListFeedbackParams, opts ...core.CallOption) (*ListFeedbackResponse, *core.ResponseMeta, error) {
 	q := url.Values{}
 	if p != nil {
 		if p.Page > 0 {
@@ -53,18 +53,36 @@ func (s *FeedbackService) List(ctx context.Context, p *ListFeedbackParams) (*Lis
 	}
 
 	var out ListFeedbackResponse
-	meta, err := s.c.Do(ctx, "GET", "/feedback", q, nil, &out)
+	meta, err := s.c.Do(ctx, "GET", "/feedback", q, nil, &out, opts...)
 	return &out, meta, err
 }
 
+// All walks every page of List, starting at p.Page.
+func (s *FeedbackService) All(ctx context.Context, p *ListFeedbackParams, opts ...core.CallOption) *core.Pager[core.Feedback] {
+	var params ListFeedbackParams
+	if p != nil {
+		params = *p
+	}
+	key := func(v core.Feedback) string { return v.ID }
+	return core.NewPager(ctx, params.Page, key, func(ctx context.Context, page int) ([]core.Feedback, core.Pagination, error) {
+		q := params
+		q.Page = page
+		res, _, err := s.List(ctx, &q, opts...)
+		if err != nil {
+			return nil, core.Pagination{}, err
+		}
+		return res.Data.Feedback, res.Data.Pagination, nil
+	})
+}
+
 type GetFeedbackResponse struct {
 	Success bool          `json:"success"`
 	Data    core.Feedback `json:"data"`
 }
 
-func (s *FeedbackService) Get(ctx context.Context, feedbackID string) (*GetFeedbackResponse, *core.ResponseMeta, error) {
+func (s *FeedbackService) Get(ctx context.Context, feedbackID string, opts ...core.CallOption) (*GetFeedbackResponse, *core.ResponseMeta, error) {
 	var out GetFeedbackResponse
-	meta, err := s.c.Do(ctx, "GET", "/feedback/"+feedbackID, nil, nil, &out)
+	meta, err := s.c.Do(ctx, "GET", "/feedback/"+feedbackID, nil, nil, &out, opts...)
 	return &out, meta, err
 }
 
@@ -78,8 +96,8 @@ type UpdateFeedbackResponse struct {
 	Data    core.Feedback `json:"data"`
 }
 
-func (s *FeedbackService) Update(ctx context.Context, feedbackID string, req UpdateFeedbackRequest) (*UpdateFeedbackResponse, *core.ResponseMeta, error) {
+func (s *FeedbackService) Update(ctx context.Context, feedbackID string, req UpdateFeedbackRequest, opts ...core.CallOption) (*UpdateFeedbackResponse, *core.ResponseMeta, error) {
 	var out UpdateFeedbackResponse
-	meta, err := s.c.Do(ctx, "PATCH", "/feedback/"+feedbackID, nil, req, &out)
+	meta, err := s.c.Do(ctx, "PATCH", "/feedback/"+feedbackID, nil, req, &out, opts...)
 	return &out, meta, err
 }
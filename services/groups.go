@@ -27,7 +27,7 @@ type ListGroupsResponse struct {
 	} `json:"data"`
 }
 
-func (s *GroupsService) List(ctx context.Context, p *ListGroupsParams) (*ListGroupsResponse, *core.ResponseMeta, error) {
+func (s *GroupsService) List(ctx context.Context, p *ListGroupsParams, opts ...core.CallOption) (*ListGroupsResponse, *core.ResponseMeta, error) {
 	q := url.Values{}
 	if p != nil {
 		if p.Page > 0 {
@@ -44,10 +44,28 @@ func (s *GroupsService) List(ctx context.Context, p *ListGroupsParams) (*ListGro
 		}
 	}
 	var out ListGroupsResponse
-	meta, err := s.c.Do(ctx, "GET", "/groups", q, nil, &out)
+	meta, err := s.c.Do(ctx, "GET", "/groups", q, nil, &out, opts...)
 	return &out, meta, err
 }
 
+// All walks every page of List, starting at p.Page.
+func (s *GroupsService) All(ctx context.Context, p *ListGroupsParams, opts ...core.CallOption) *core.Pager[core.Group] {
+	var params ListGroupsParams
+	if p != nil {
+		params = *p
+	}
+	key := func(v core.Group) string { return v.ID }
+	return core.NewPager(ctx, params.Page, key, func(ctx context.Context, page int) ([]core.Group, core.Pagination, error) {
+		q := params
+		q.Page = page
+		res, _, err := s.List(ctx, &q, opts...)
+		if err != nil {
+			return nil, core.Pagination{}, err
+		}
+		return res.Data.Groups, res.Data.Pagination, nil
+	})
+}
+
 type CreateGroupRequest struct {
 	Name         string `json:"name"`
 	Description  string `json:"description,omitempty"`
@@ -63,9 +81,9 @@ type GroupResponse struct {
 	} `json:"data"`
 }
 
-func (s *GroupsService) Create(ctx context.Context, req CreateGroupRequest) (*GroupResponse, *core.ResponseMeta, error) {
+func (s *GroupsService) Create(ctx context.Context, req CreateGroupRequest, opts ...core.CallOption) (*GroupResponse, *core.ResponseMeta, error) {
 	var out GroupResponse
-	meta, err := s.c.Do(ctx, "POST", "/groups", nil, req, &out)
+	meta, err := s.c.Do(ctx, "POST", "/groups", nil, req, &out, opts...)
 	return &out, meta, err
 }
 
@@ -79,9 +97,9 @@ type GetGroupResponse struct {
 	} `json:"data"`
 }
 
-func (s *GroupsService) Get(ctx context.Context, groupID string) (*GetGroupResponse, *core.ResponseMeta, error) {
+func (s *GroupsService) Get(ctx context.Context, groupID string, opts ...core.CallOption) (*GetGroupResponse, *core.ResponseMeta, error) {
 	var out GetGroupResponse
-	meta, err := s.c.Do(ctx, "GET", "/groups/"+groupID, nil, nil, &out)
+	meta, err := s.c.Do(ctx, "GET", "/groups/"+groupID, nil, nil, &out, opts...)
 	return &out, meta, err
 }
 
@@ -93,9 +111,9 @@ type UpdateGroupRequest struct {
 	IsActive     *bool   `json:"is_active,omitempty"`
 }
 
-func (s *GroupsService) Update(ctx context.Context, groupID string, req UpdateGroupRequest) (*GroupResponse, *core.ResponseMeta, error) {
+func (s *GroupsService) Update(ctx context.Context, groupID string, req UpdateGroupRequest, opts ...core.CallOption) (*GroupResponse, *core.ResponseMeta, error) {
 	var out GroupResponse
-	meta, err := s.c.Do(ctx, "PATCH", "/groups/"+groupID, nil, req, &out)
+	meta, err := s.c.Do(ctx, "PATCH", "/groups/"+groupID, nil, req, &out, opts...)
 	return &out, meta, err
 }
 
@@ -107,8 +125,8 @@ type DeleteGroupResponse struct {
 	} `json:"data"`
 }
 
-func (s *GroupsService) Delete(ctx context.Context, groupID string) (*DeleteGroupResponse, *core.ResponseMeta, error) {
+func (s *GroupsService) Delete(ctx context.Context, groupID string, opts ...core.CallOption) (*DeleteGroupResponse, *core.ResponseMeta, error) {
 	var out DeleteGroupResponse
-	meta, err := s.c.Do(ctx, "DELETE", "/groups/"+groupID, nil, nil, &out)
+	meta, err := s.c.Do(ctx, "DELETE", "/groups/"+groupID, nil, nil, &out, opts...)
 	return &out, meta, err
 }
@@ -15,7 +15,7 @@ func NewOrders(c *core.Client) *OrdersService { return &OrdersService{c: c} }
 type ListOrdersParams struct {
 	Page          int
 	Limit         int
-	Status        string
+	Status        core.OrderStatus
 	ProductID     string
 	CustomerEmail string
 }
@@ -28,7 +28,7 @@ type ListOrdersResponse struct {
 	} `json:"data"`
 }
 
-func (s *OrdersService) List(ctx context.Context, p *ListOrdersParams) (*ListOrdersResponse, *core.ResponseMeta, error) {
+func (s *OrdersService) List(ctx context.Context, p *ListOrdersParams, opts ...core.CallOption) (*ListOrdersResponse, *core.ResponseMeta, error) {
 	q := url.Values{}
 	if p != nil {
 		if p.Page > 0 {
@@ -38,7 +38,7 @@ func (s *OrdersService) List(ctx context.Context, p *ListOrdersParams) (*ListOrd
 			q.Set("limit", strconv.Itoa(p.Limit))
 		}
 		if p.Status != "" {
-			q.Set("status", p.Status)
+			q.Set("status", string(p.Status))
 		}
 		if p.ProductID != "" {
 			q.Set("product_id", p.ProductID)
@@ -49,19 +49,37 @@ func (s *OrdersService) List(ctx context.Context, p *ListOrdersParams) (*ListOrd
 	}
 
 	var out ListOrdersResponse
-	meta, err := s.c.Do(ctx, "GET", "/orders", q, nil, &out)
+	meta, err := s.c.Do(ctx, "GET", "/orders", q, nil, &out, opts...)
 	return &out, meta, err
 }
 
+// All walks every page of List, starting at p.Page.
+func (s *OrdersService) All(ctx context.Context, p *ListOrdersParams, opts ...core.CallOption) *core.Pager[core.Order] {
+	var params ListOrdersParams
+	if p != nil {
+		params = *p
+	}
+	key := func(v core.Order) string { return v.ID }
+	return core.NewPager(ctx, params.Page, key, func(ctx context.Context, page int) ([]core.Order, core.Pagination, error) {
+		q := params
+		q.Page = page
+		res, _, err := s.List(ctx, &q, opts...)
+		if err != nil {
+			return nil, core.Pagination{}, err
+		}
+		return res.Data.Orders, res.Data.Pagination, nil
+	})
+}
+
 type CreateOrderRequest struct {
 	ProductID     string `json:"product_id"`
 	CustomerEmail string `json:"customer_email"`
 	Quantity      int    `json:"quantity"`
 
-	CustomerName  string `json:"customer_name,omitempty"`
-	PaymentMethod string `json:"payment_method,omitempty"` // stripe|paypal|crypto|cashapp
-	CustomFields  any    `json:"custom_fields,omitempty"`  // docs: object
-	AffiliateCode string `json:"affiliate_code,omitempty"`
+	CustomerName  string             `json:"customer_name,omitempty"`
+	PaymentMethod core.PaymentMethod `json:"payment_method,omitempty"`
+	CustomFields  any                `json:"custom_fields,omitempty"` // docs: object
+	AffiliateCode string             `json:"affiliate_code,omitempty"`
 }
 
 type OrderResponse struct {
@@ -71,23 +89,23 @@ type OrderResponse struct {
 	} `json:"data"`
 }
 
-func (s *OrdersService) Create(ctx context.Context, req CreateOrderRequest) (*OrderResponse, *core.ResponseMeta, error) {
+func (s *OrdersService) Create(ctx context.Context, req CreateOrderRequest, opts ...core.CallOption) (*OrderResponse, *core.ResponseMeta, error) {
 	var out OrderResponse
-	meta, err := s.c.Do(ctx, "POST", "/orders", nil, req, &out)
+	meta, err := s.c.Do(ctx, "POST", "/orders", nil, req, &out, opts...)
 	return &out, meta, err
 }
 
-func (s *OrdersService) Get(ctx context.Context, orderID string) (*OrderResponse, *core.ResponseMeta, error) {
+func (s *OrdersService) Get(ctx context.Context, orderID string, opts ...core.CallOption) (*OrderResponse, *core.ResponseMeta, error) {
 	var out OrderResponse
-	meta, err := s.c.Do(ctx, "GET", "/orders/"+orderID, nil, nil, &out)
+	meta, err := s.c.Do(ctx, "GET", "/orders/"+orderID, nil, nil, &out, opts...)
 	return &out, meta, err
 }
 
 type UpdateOrderRequest struct {
-	Status        *string `json:"status,omitempty"` // pending|completed|canceled|refunded
-	CustomerName  *string `json:"customer_name,omitempty"`
-	TransactionID *string `json:"transaction_id,omitempty"`
-	CustomFields  any     `json:"custom_fields,omitempty"`
+	Status        *core.OrderStatus `json:"status,omitempty"`
+	CustomerName  *string           `json:"customer_name,omitempty"`
+	TransactionID *string           `json:"transaction_id,omitempty"`
+	CustomFields  any               `json:"custom_fields,omitempty"`
 }
 
 type UpdateOrderResponse struct {
@@ -100,9 +118,9 @@ type UpdateOrderResponse struct {
 	} `json:"data"`
 }
 
-func (s *OrdersService) Update(ctx context.Context, orderID string, req UpdateOrderRequest) (*UpdateOrderResponse, *core.ResponseMeta, error) {
+func (s *OrdersService) Update(ctx context.Context, orderID string, req UpdateOrderRequest, opts ...core.CallOption) (*UpdateOrderResponse, *core.ResponseMeta, error) {
 	var out UpdateOrderResponse
-	meta, err := s.c.Do(ctx, "PATCH", "/orders/"+orderID, nil, req, &out)
+	meta, err := s.c.Do(ctx, "PATCH", "/orders/"+orderID, nil, req, &out, opts...)
 	return &out, meta, err
 }
 
@@ -114,8 +132,8 @@ type DeleteOrderResponse struct {
 	} `json:"data"`
 }
 
-func (s *OrdersService) Delete(ctx context.Context, orderID string) (*DeleteOrderResponse, *core.ResponseMeta, error) {
+func (s *OrdersService) Delete(ctx context.Context, orderID string, opts ...core.CallOption) (*DeleteOrderResponse, *core.ResponseMeta, error) {
 	var out DeleteOrderResponse
-	meta, err := s.c.Do(ctx, "DELETE", "/orders/"+orderID, nil, nil, &out)
+	meta, err := s.c.Do(ctx, "DELETE", "/orders/"+orderID, nil, nil, &out, opts...)
 	return &out, meta, err
 }
@@ -27,7 +27,7 @@ type ListProductsResponse struct {
 	} `json:"data"`
 }
 
-func (s *ProductsService) List(ctx context.Context, p *ListProductsParams) (*ListProductsResponse, *core.ResponseMeta, error) {
+func (s *ProductsService) List(ctx context.Context, p *ListProductsParams, opts ...core.CallOption) (*ListProductsResponse, *core.ResponseMeta, error) {
 	q := url.Values{}
 	if p != nil {
 		if p.Page > 0 {
@@ -44,14 +44,32 @@ func (s *ProductsService) List(ctx context.Context, p *ListProductsParams) (*Lis
 		}
 	}
 	var out ListProductsResponse
-	meta, err := s.c.Do(ctx, "GET", "/products", q, nil, &out)
+	meta, err := s.c.Do(ctx, "GET", "/products", q, nil, &out, opts...)
 	return &out, meta, err
 }
 
+// All walks every page of List, starting at p.Page.
+func (s *ProductsService) All(ctx context.Context, p *ListProductsParams, opts ...core.CallOption) *core.Pager[core.Product] {
+	var params ListProductsParams
+	if p != nil {
+		params = *p
+	}
+	key := func(v core.Product) string { return v.ID }
+	return core.NewPager(ctx, params.Page, key, func(ctx context.Context, page int) ([]core.Product, core.Pagination, error) {
+		q := params
+		q.Page = page
+		res, _, err := s.List(ctx, &q, opts...)
+		if err != nil {
+			return nil, core.Pagination{}, err
+		}
+		return res.Data.Products, res.Data.Pagination, nil
+	})
+}
+
 type CreateProductRequest struct {
-	Name         string `json:"name"`
-	PriceInCents int    `json:"price_in_cents"`
-	DeliveryType string `json:"delivery_type"` // file|serials|service|dynamic
+	Name         string            `json:"name"`
+	PriceInCents core.Money        `json:"price_in_cents"`
+	DeliveryType core.DeliveryType `json:"delivery_type"`
 
 	Description     string `json:"description,omitempty"`
 	ImageURL        string `json:"image_url,omitempty"`
@@ -94,34 +112,34 @@ type GetProductResponse struct {
 	} `json:"data"`
 }
 
-func (s *ProductsService) Create(ctx context.Context, req CreateProductRequest) (*GetProductResponse, *core.ResponseMeta, error) {
+func (s *ProductsService) Create(ctx context.Context, req CreateProductRequest, opts ...core.CallOption) (*GetProductResponse, *core.ResponseMeta, error) {
 	var out GetProductResponse
-	meta, err := s.c.Do(ctx, "POST", "/products", nil, req, &out)
+	meta, err := s.c.Do(ctx, "POST", "/products", nil, req, &out, opts...)
 	return &out, meta, err
 }
 
-func (s *ProductsService) Get(ctx context.Context, productID string) (*GetProductResponse, *core.ResponseMeta, error) {
+func (s *ProductsService) Get(ctx context.Context, productID string, opts ...core.CallOption) (*GetProductResponse, *core.ResponseMeta, error) {
 	var out GetProductResponse
-	meta, err := s.c.Do(ctx, "GET", "/products/"+productID, nil, nil, &out)
+	meta, err := s.c.Do(ctx, "GET", "/products/"+productID, nil, nil, &out, opts...)
 	return &out, meta, err
 }
 
 type UpdateProductRequest struct {
-	Name            *string `json:"name,omitempty"`
-	Description     *string `json:"description,omitempty"`
-	ImageURL        *string `json:"image_url,omitempty"`
-	PriceInCents    *int    `json:"price_in_cents,omitempty"`
-	DeliveryType    *string `json:"delivery_type,omitempty"`
-	IsActive        *bool   `json:"is_active,omitempty"`
-	StockQuantity   *int    `json:"stock_quantity,omitempty"`
-	MinimumQuantity *int    `json:"minimum_quantity,omitempty"`
-	MaximumQuantity *int    `json:"maximum_quantity,omitempty"`
-	Unlisted        *bool   `json:"unlisted,omitempty"`
-	IsPrivate       *bool   `json:"is_private,omitempty"`
-	OnHold          *bool   `json:"on_hold,omitempty"`
-	Warranty        *string `json:"warranty,omitempty"`
-	ProductTerms    *string `json:"product_terms,omitempty"`
-	GroupID         *string `json:"group_id,omitempty"`
+	Name            *string            `json:"name,omitempty"`
+	Description     *string            `json:"description,omitempty"`
+	ImageURL        *string            `json:"image_url,omitempty"`
+	PriceInCents    *core.Money        `json:"price_in_cents,omitempty"`
+	DeliveryType    *core.DeliveryType `json:"delivery_type,omitempty"`
+	IsActive        *bool              `json:"is_active,omitempty"`
+	StockQuantity   *int               `json:"stock_quantity,omitempty"`
+	MinimumQuantity *int               `json:"minimum_quantity,omitempty"`
+	MaximumQuantity *int               `json:"maximum_quantity,omitempty"`
+	Unlisted        *bool              `json:"unlisted,omitempty"`
+	IsPrivate       *bool              `json:"is_private,omitempty"`
+	OnHold          *bool              `json:"on_hold,omitempty"`
+	Warranty        *string            `json:"warranty,omitempty"`
+	ProductTerms    *string            `json:"product_terms,omitempty"`
+	GroupID         *string            `json:"group_id,omitempty"`
 
 	Serials           *[]string `json:"serials,omitempty"`
 	FileURL           *string   `json:"file_url,omitempty"`
@@ -143,9 +161,9 @@ type UpdateProductRequest struct {
 	WebhookURLs     any `json:"webhook_urls,omitempty"`
 }
 
-func (s *ProductsService) Update(ctx context.Context, productID string, req UpdateProductRequest) (*GetProductResponse, *core.ResponseMeta, error) {
+func (s *ProductsService) Update(ctx context.Context, productID string, req UpdateProductRequest, opts ...core.CallOption) (*GetProductResponse, *core.ResponseMeta, error) {
 	var out GetProductResponse
-	meta, err := s.c.Do(ctx, "PATCH", "/products/"+productID, nil, req, &out)
+	meta, err := s.c.Do(ctx, "PATCH", "/products/"+productID, nil, req, &out, opts...)
 	return &out, meta, err
 }
 
@@ -158,8 +176,8 @@ type DeleteProductResponse struct {
 	} `json:"data"`
 }
 
-func (s *ProductsService) Delete(ctx context.Context, productID string) (*DeleteProductResponse, *core.ResponseMeta, error) {
+func (s *ProductsService) Delete(ctx context.Context, productID string, opts ...core.CallOption) (*DeleteProductResponse, *core.ResponseMeta, error) {
 	var out DeleteProductResponse
-	meta, err := s.c.Do(ctx, "DELETE", "/products/"+productID, nil, nil, &out)
+	meta, err := s.c.Do(ctx, "DELETE", "/products/"+productID, nil, nil, &out, opts...)
 	return &out, meta, err
 }
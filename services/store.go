@@ -18,8 +18,8 @@ type GetStoreResponse struct {
 	} `json:"data"`
 }
 
-func (s *StoreService) Get(ctx context.Context) (*GetStoreResponse, *core.ResponseMeta, error) {
+func (s *StoreService) Get(ctx context.Context, opts ...core.CallOption) (*GetStoreResponse, *core.ResponseMeta, error) {
 	var out GetStoreResponse
-	meta, err := s.c.Do(ctx, "GET", "/store", nil, nil, &out)
+	meta, err := s.c.Do(ctx, "GET", "/store", nil, nil, &out, opts...)
 	return &out, meta, err
 }
@@ -15,8 +15,8 @@ func NewTickets(c *core.Client) *TicketsService { return &TicketsService{c: c} }
 type ListTicketsParams struct {
 	Page     int
 	Limit    int
-	Status   string
-	Priority string
+	Status   core.TicketStatus
+	Priority core.TicketPriority
 	Email    string
 }
 
@@ -28,7 +28,7 @@ type ListTicketsResponse struct {
 	} `json:"data"`
 }
 
-func (s *TicketsService) List(ctx context.Context, p *ListTicketsParams) (*ListTicketsResponse, *core.ResponseMeta, error) {
+func (s *TicketsService) List(ctx context.Context, p *ListTicketsParams, opts ...core.CallOption) (*ListTicketsResponse, *core.ResponseMeta, error) {
 	q := url.Values{}
 	if p != nil {
 		if p.Page > 0 {
@@ -38,10 +38,10 @@ func (s *TicketsService) List(ctx context.Context, p *ListTicketsParams) (*ListT
 			q.Set("limit", strconv.Itoa(p.Limit))
 		}
 		if p.Status != "" {
-			q.Set("status", p.Status)
+			q.Set("status", string(p.Status))
 		}
 		if p.Priority != "" {
-			q.Set("priority", p.Priority)
+			q.Set("priority", string(p.Priority))
 		}
 		if p.Email != "" {
 			q.Set("email", p.Email)
@@ -49,10 +49,28 @@ func (s *TicketsService) List(ctx context.Context, p *ListTicketsParams) (*ListT
 	}
 
 	var out ListTicketsResponse
-	meta, err := s.c.Do(ctx, "GET", "/tickets", q, nil, &out)
+	meta, err := s.c.Do(ctx, "GET", "/tickets", q, nil, &out, opts...)
 	return &out, meta, err
 }
 
+// All walks every page of List, starting at p.Page.
+func (s *TicketsService) All(ctx context.Context, p *ListTicketsParams, opts ...core.CallOption) *core.Pager[core.Ticket] {
+	var params ListTicketsParams
+	if p != nil {
+		params = *p
+	}
+	key := func(v core.Ticket) string { return v.ID }
+	return core.NewPager(ctx, params.Page, key, func(ctx context.Context, page int) ([]core.Ticket, core.Pagination, error) {
+		q := params
+		q.Page = page
+		res, _, err := s.List(ctx, &q, opts...)
+		if err != nil {
+			return nil, core.Pagination{}, err
+		}
+		return res.Data.Tickets, res.Data.Pagination, nil
+	})
+}
+
 type GetTicketResponse struct {
 	Success bool `json:"success"`
 	Data    struct {
@@ -61,34 +79,34 @@ type GetTicketResponse struct {
 	} `json:"data"`
 }
 
-func (s *TicketsService) Get(ctx context.Context, ticketID string) (*GetTicketResponse, *core.ResponseMeta, error) {
+func (s *TicketsService) Get(ctx context.Context, ticketID string, opts ...core.CallOption) (*GetTicketResponse, *core.ResponseMeta, error) {
 	var out GetTicketResponse
-	meta, err := s.c.Do(ctx, "GET", "/tickets/"+ticketID, nil, nil, &out)
+	meta, err := s.c.Do(ctx, "GET", "/tickets/"+ticketID, nil, nil, &out, opts...)
 	return &out, meta, err
 }
 
 type ReplyTicketRequest struct {
-	Message string `json:"message"`
-	Status  string `json:"status,omitempty"` // optional
+	Message string            `json:"message"`
+	Status  core.TicketStatus `json:"status,omitempty"` // optional
 }
 
 type ReplyTicketResponse struct {
 	Success bool `json:"success"`
 	Data    struct {
 		Message      core.TicketMessage `json:"message"`
-		TicketStatus string             `json:"ticket_status"`
+		TicketStatus core.TicketStatus  `json:"ticket_status"`
 	} `json:"data"`
 }
 
-func (s *TicketsService) Reply(ctx context.Context, ticketID string, req ReplyTicketRequest) (*ReplyTicketResponse, *core.ResponseMeta, error) {
+func (s *TicketsService) Reply(ctx context.Context, ticketID string, req ReplyTicketRequest, opts ...core.CallOption) (*ReplyTicketResponse, *core.ResponseMeta, error) {
 	var out ReplyTicketResponse
-	meta, err := s.c.Do(ctx, "POST", "/tickets/"+ticketID+"/reply", nil, req, &out)
+	meta, err := s.c.Do(ctx, "POST", "/tickets/"+ticketID+"/reply", nil, req, &out, opts...)
 	return &out, meta, err
 }
 
 type UpdateTicketRequest struct {
-	Status   *string `json:"status,omitempty"`
-	Priority *string `json:"priority,omitempty"`
+	Status   *core.TicketStatus   `json:"status,omitempty"`
+	Priority *core.TicketPriority `json:"priority,omitempty"`
 }
 
 type UpdateTicketResponse struct {
@@ -98,8 +116,8 @@ type UpdateTicketResponse struct {
 	} `json:"data"`
 }
 
-func (s *TicketsService) Update(ctx context.Context, ticketID string, req UpdateTicketRequest) (*UpdateTicketResponse, *core.ResponseMeta, error) {
+func (s *TicketsService) Update(ctx context.Context, ticketID string, req UpdateTicketRequest, opts ...core.CallOption) (*UpdateTicketResponse, *core.ResponseMeta, error) {
 	var out UpdateTicketResponse
-	meta, err := s.c.Do(ctx, "PATCH", "/tickets/"+ticketID, nil, req, &out)
+	meta, err := s.c.Do(ctx, "PATCH", "/tickets/"+ticketID, nil, req, &out, opts...)
 	return &out, meta, err
 }